@@ -15,7 +15,7 @@ import "image"
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
 func (c *Context) Button(text string) EventHandler {
 	pc := caller()
-	id := c.idStack.push(idPartFromCaller(pc))
+	id := c.idStack.push(c.nextIDPart(pc))
 	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
 		return c.button(text, optionAlignCenter, id)
 	})
@@ -24,14 +24,14 @@ func (c *Context) Button(text string) EventHandler {
 func (c *Context) button(text string, opt option, id widgetID) (EventHandler, error) {
 	return c.widget(id, opt, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
 		var e EventHandler
-		if c.pointing.justPressed() && c.focus == id {
+		if (c.pointing.justPressed() && c.focus == id) || c.navActivated(id) {
 			e = &eventHandler{}
 		}
 		return e
 	}, func(bounds image.Rectangle) {
-		c.drawWidgetFrame(id, bounds, colorButton, opt)
+		c.drawWidgetFrame(id, bounds, ColorButton, opt)
 		if len(text) > 0 {
-			c.drawWidgetText(text, bounds, colorText, opt)
+			c.drawWidgetText(text, bounds, ColorText, opt)
 		}
 	})
 }
@@ -42,14 +42,14 @@ func (c *Context) spinButtons(id widgetID) (up, down EventHandler) {
 	c.GridCell(func(bounds image.Rectangle) {
 		c.SetGridLayout(nil, []int{-1, -1})
 		up = c.wrapEventHandlerAndError(func() (EventHandler, error) {
-			e, err := c.spinButton(true, optionAlignCenter, upID, downID)
+			e, err := c.spinButton(true, optionAlignCenter|optionNoNavigation, upID, downID)
 			if err != nil {
 				return nil, err
 			}
 			return e, nil
 		})
 		down = c.wrapEventHandlerAndError(func() (EventHandler, error) {
-			e, err := c.spinButton(false, optionAlignCenter, upID, downID)
+			e, err := c.spinButton(false, optionAlignCenter|optionNoNavigation, upID, downID)
 			if err != nil {
 				return nil, err
 			}
@@ -71,11 +71,11 @@ func (c *Context) spinButton(up bool, opt option, upID, downID widgetID) (EventH
 		}
 		return e
 	}, func(bounds image.Rectangle) {
-		c.drawWidgetFrame(id, bounds, colorButton, opt)
-		icon := iconDown
+		c.drawWidgetFrame(id, bounds, ColorButton, opt)
+		icon := IconDown
 		if up {
-			icon = iconUp
+			icon = IconUp
 		}
-		c.drawIcon(icon, bounds, c.style().colors[colorText])
+		c.drawIcon(icon, bounds, c.style().Colors[ColorText])
 	})
 }
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// defaultDoubleClickInterval is the default maximum interval between the two presses of a double click.
+const defaultDoubleClickInterval = 500 * time.Millisecond
+
+// SetDoubleClickInterval sets the maximum interval between the two presses of a double click.
+//
+// If interval is 0 or negative, the default interval (500ms) is used.
+func (c *Context) SetDoubleClickInterval(interval time.Duration) {
+	c.doubleClickInterval = max(interval, 0)
+}
+
+// DoubleClickInterval returns the maximum interval between the two presses of a double click.
+func (c *Context) DoubleClickInterval() time.Duration {
+	if c.doubleClickInterval == 0 {
+		return defaultDoubleClickInterval
+	}
+	return c.doubleClickInterval
+}
+
+// doubleClickTicks returns the double click interval in ticks.
+func (c *Context) doubleClickTicks() int {
+	return int(c.DoubleClickInterval() * time.Duration(tps()) / time.Second)
+}
+
+// SecondaryClicked returns an EventHandler to handle a secondary click on the last widget.
+//
+// A secondary click is a press of the right mouse button, or a long press on a touchscreen.
+//
+// SecondaryClicked must be called right after the widget is created, e.g.,
+//
+//	ctx.NumberField(&speed, 1)
+//	ctx.SecondaryClicked().On(func() {
+//		speed = defaultSpeed
+//	})
+//
+// The event is never fired for a widget without user interaction like Text.
+// See also [Context.ContextMenu].
+func (c *Context) SecondaryClicked() EventHandler {
+	return c.lastWidgetClicked(c.pointing.secondaryJustPressed())
+}
+
+// MiddleClicked returns an EventHandler to handle a press of the middle mouse button on the last widget.
+//
+// MiddleClicked must be called right after the widget is created. See [Context.SecondaryClicked].
+func (c *Context) MiddleClicked() EventHandler {
+	return c.lastWidgetClicked(c.pointing.buttonJustPressed(ebiten.MouseButtonMiddle))
+}
+
+// DoubleClicked returns an EventHandler to handle a double click or a double tap on the last widget.
+//
+// The interval of a double click can be changed by [Context.SetDoubleClickInterval].
+// The first press of a double click is handled by the widget as a usual click.
+//
+// DoubleClicked must be called right after the widget is created. See [Context.SecondaryClicked].
+func (c *Context) DoubleClicked() EventHandler {
+	return c.lastWidgetClicked(c.pointing.doubleClicked())
+}
+
+func (c *Context) lastWidgetClicked(clicked bool) EventHandler {
+	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		if !clicked || c.currentID == (widgetID{}) || c.hover != c.currentID {
+			return nil, nil
+		}
+		return &eventHandler{}, nil
+	})
+}
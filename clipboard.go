@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+// Clipboard is a clipboard to copy, cut and paste texts in text fields and text areas.
+type Clipboard interface {
+	// ReadText returns the text in the clipboard.
+	ReadText() (string, error)
+
+	// WriteText sets the text to the clipboard.
+	WriteText(text string) error
+}
+
+// SetClipboard sets the clipboard used by text fields and text areas.
+//
+// If cb is nil, an in-memory clipboard is used. This is the default behavior.
+// The in-memory clipboard is not shared with other applications.
+func (d *DebugUI) SetClipboard(cb Clipboard) {
+	d.ctx.clipboard = cb
+}
+
+// memoryClipboard is a Clipboard that keeps the text in memory.
+type memoryClipboard struct {
+	text string
+}
+
+func (m *memoryClipboard) ReadText() (string, error) {
+	return m.text, nil
+}
+
+func (m *memoryClipboard) WriteText(text string) error {
+	m.text = text
+	return nil
+}
+
+func (c *Context) currentClipboard() Clipboard {
+	if c.clipboard == nil {
+		return &c.memoryClipboard
+	}
+	return c.clipboard
+}
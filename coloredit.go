@@ -0,0 +1,453 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// colorEditSquareSize is the size of the saturation-value square of a color edit popup.
+	colorEditSquareSize = 160
+
+	// colorEditBarWidth is the width of the hue and alpha bars of a color edit popup.
+	colorEditBarWidth = 16
+
+	// colorEditCells is the number of the cells to draw a gradient along one side of the square or a bar.
+	colorEditCells = 32
+
+	// colorEditCheckerSize is the size of a checkerboard square drawn behind a translucent color.
+	colorEditCheckerSize = 4
+)
+
+var colorEditPopupIDPart = idPartFromString("debugui-color-edit-popup")
+
+// colorEditState is the state of a color edit widget kept by its container.
+type colorEditState struct {
+	// nrgba is the color being edited in the non-premultiplied form.
+	// This is kept as the conversion from a premultiplied color loses the precision of the channels with a low alpha.
+	nrgba color.NRGBA
+
+	// rgba is the color set by the widget last time, to detect a change of the color outside of the widget.
+	rgba color.RGBA
+
+	// h is the hue in [0, 360). s and v are the saturation and the value in [0, 1].
+	// They are kept as the hue and the saturation cannot be restored from a color without saturation or value.
+	h, s, v float64
+
+	initialized bool
+}
+
+// ColorEdit creates a color edit widget to modify the color.
+//
+// The widget shows a swatch of the color with its hex code. Clicking the swatch opens a popup to edit the color
+// with a saturation-value square, hue and alpha bars, a hex code field, and number fields of the RGB and HSV channels.
+// The channels are edited in the non-premultiplied alpha form.
+//
+// ColorEdit returns an EventHandler to handle color change events.
+// A returned EventHandler is never nil.
+//
+// A ColorEdit widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) ColorEdit(clr *color.RGBA) EventHandler {
+	pc := caller()
+	idPart := c.nextIDPart(pc)
+	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		var e EventHandler
+		var err error
+		c.idScopeFromIDPart(idPart, func(id widgetID) {
+			e, err = c.colorEdit(clr, id)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	})
+}
+
+func (c *Context) colorEdit(clr *color.RGBA, id widgetID) (EventHandler, error) {
+	defer recordEdit(c, id, clr, *clr)
+
+	state := c.currentContainer().colorEditState(id)
+	if !state.initialized || state.rgba != *clr {
+		state.setRGBA(*clr)
+	}
+
+	// Create the popup before the swatch, so that the press opening the popup doesn't close it in the same frame.
+	var changed bool
+	var popupErr error
+	if err := c.popup(colorEditPopupIDPart, func(layout ContainerLayout) {
+		// The fields in the popup edit temporary values, so record the edit of clr instead of the fields.
+		c.withoutEditHistory(func() {
+			changed, popupErr = c.colorEditPopup(state)
+		})
+	}); err != nil {
+		return nil, err
+	}
+	if popupErr != nil {
+		return nil, popupErr
+	}
+	if changed {
+		state.rgba = color.RGBAModel.Convert(state.nrgba).(color.RGBA)
+		*clr = state.rgba
+	}
+
+	popupID := id.push(colorEditPopupIDPart)
+	if _, err := c.widget(id, 0, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+		if c.pointing.justPressed() && c.focus == id {
+			c.openPopup(popupID, c.pointingPosition())
+		} else if c.navActivated(id) {
+			// Open the popup below the swatch, as the pointing position is not related to the keyboard or the gamepad.
+			c.openPopup(popupID, image.Pt(bounds.Min.X, bounds.Max.Y))
+		}
+		return nil
+	}, func(bounds image.Rectangle) {
+		c.drawColorSwatch(id, bounds, *clr)
+	}); err != nil {
+		return nil, err
+	}
+
+	if !changed {
+		return nil, nil
+	}
+	return &eventHandler{}, nil
+}
+
+// colorEditPopup creates the content of a color edit popup, and reports whether the color is changed.
+func (c *Context) colorEditPopup(state *colorEditState) (bool, error) {
+	var changed bool
+
+	c.SetGridLayout([]int{colorEditSquareSize, colorEditBarWidth, colorEditBarWidth}, []int{colorEditSquareSize})
+	for _, f := range []func(*colorEditState) (bool, error){
+		c.colorEditSquare,
+		c.colorEditHueBar,
+		c.colorEditAlphaBar,
+	} {
+		ch, err := f(state)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || ch
+	}
+
+	// The fields are laid out in the width of the square and the bars. Relative widths don't work, as the popup fits its content.
+	spacing := c.style().Spacing
+	width := colorEditSquareSize + 2*colorEditBarWidth + 2*spacing
+	labelWidth := c.textWidth("M") + 2*c.style().Padding
+	fieldWidth := (width - 3*labelWidth - 5*spacing) / 3
+	c.SetGridLayout([]int{labelWidth, fieldWidth, labelWidth, fieldWidth, labelWidth, fieldWidth}, nil)
+
+	n := state.nrgba
+	rgb := [...]int{int(n.R), int(n.G), int(n.B)}
+	var rgbChanged bool
+	for i, name := range [...]string{"R", "G", "B"} {
+		c.Text(name)
+		e, err := c.numberField(&rgb[i], 1, idPartFromString(name), optionAlignRight)
+		if err != nil {
+			return false, err
+		}
+		if e != nil {
+			rgbChanged = true
+		}
+	}
+	if rgbChanged {
+		state.setNRGBA(color.NRGBA{
+			R: uint8(clamp(rgb[0], 0, 255)),
+			G: uint8(clamp(rgb[1], 0, 255)),
+			B: uint8(clamp(rgb[2], 0, 255)),
+			A: n.A,
+		})
+		changed = true
+	}
+
+	hsv := [...]int{int(math.Round(state.h)), int(math.Round(state.s * 100)), int(math.Round(state.v * 100))}
+	var hsvChanged bool
+	for i, name := range [...]string{"H", "S", "V"} {
+		c.Text(name)
+		e, err := c.numberField(&hsv[i], 1, idPartFromString(name), optionAlignRight)
+		if err != nil {
+			return false, err
+		}
+		if e != nil {
+			hsvChanged = true
+		}
+	}
+	if hsvChanged {
+		state.setHSV(float64(clamp(hsv[0], 0, 359)), float64(clamp(hsv[1], 0, 100))/100, float64(clamp(hsv[2], 0, 100))/100, n.A)
+		changed = true
+	}
+
+	hexLabelWidth := c.textWidth("Hex") + 2*c.style().Padding
+	c.SetGridLayout([]int{labelWidth, fieldWidth, hexLabelWidth, width - labelWidth - fieldWidth - hexLabelWidth - 3*spacing}, nil)
+	n = state.nrgba
+	a := int(n.A)
+	c.Text("A")
+	e, err := c.numberField(&a, 1, idPartFromString("A"), optionAlignRight)
+	if err != nil {
+		return false, err
+	}
+	if e != nil {
+		n.A = uint8(clamp(a, 0, 255))
+		state.setHSV(state.h, state.s, state.v, n.A)
+		changed = true
+	}
+
+	c.Text("Hex")
+	id := c.idStack.push(idPartFromString("Hex"))
+	buf := colorHex(n)
+	e, err = c.textFieldRaw(&buf, id, 0)
+	if err != nil {
+		return false, err
+	}
+	if e != nil {
+		e.On(func() {
+			c.setFocus(widgetID{})
+			if n2, ok := parseColorHex(buf); ok && n2 != n {
+				state.setNRGBA(n2)
+				changed = true
+			}
+		})
+	}
+
+	return changed, nil
+}
+
+// colorEditSquare creates the square to edit the saturation and the value.
+func (c *Context) colorEditSquare(state *colorEditState) (bool, error) {
+	id := c.idStack.push(idPartFromString("saturation-value"))
+	var changed bool
+	_, err := c.widget(id, optionNoNavigation, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+		if c.focus == id && c.pointing.pressed() {
+			p := c.pointingPosition().Sub(bounds.Min)
+			s := clamp(float64(p.X)/float64(bounds.Dx()-1), 0, 1)
+			v := 1 - clamp(float64(p.Y)/float64(bounds.Dy()-1), 0, 1)
+			if s != state.s || v != state.v {
+				state.setHSV(state.h, s, v, state.nrgba.A)
+				changed = true
+			}
+		}
+		return nil
+	}, func(bounds image.Rectangle) {
+		for j := range colorEditCells {
+			for i := range colorEditCells {
+				s := (float64(i) + 0.5) / colorEditCells
+				v := 1 - (float64(j)+0.5)/colorEditCells
+				c.drawRect(colorEditCell(bounds, i, j, colorEditCells, colorEditCells), hsvToNRGBA(state.h, s, v, 255))
+			}
+		}
+		p := bounds.Min.Add(image.Pt(int(math.Round(state.s*float64(bounds.Dx()-1))), int(math.Round((1-state.v)*float64(bounds.Dy()-1)))))
+		c.drawColorEditMarker(image.Rect(p.X-3, p.Y-3, p.X+4, p.Y+4))
+	})
+	return changed, err
+}
+
+// colorEditHueBar creates the vertical bar to edit the hue.
+func (c *Context) colorEditHueBar(state *colorEditState) (bool, error) {
+	id := c.idStack.push(idPartFromString("hue"))
+	var changed bool
+	_, err := c.widget(id, optionNoNavigation, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+		if c.focus == id && c.pointing.pressed() {
+			y := clamp(float64(c.pointingPosition().Y-bounds.Min.Y)/float64(bounds.Dy()-1), 0, 1)
+			if h := math.Min(y*360, 359); h != state.h {
+				state.setHSV(h, state.s, state.v, state.nrgba.A)
+				changed = true
+			}
+		}
+		return nil
+	}, func(bounds image.Rectangle) {
+		for j := range colorEditCells {
+			h := (float64(j) + 0.5) / colorEditCells * 360
+			c.drawRect(colorEditCell(bounds, 0, j, 1, colorEditCells), hsvToNRGBA(h, 1, 1, 255))
+		}
+		y := bounds.Min.Y + int(math.Round(state.h/360*float64(bounds.Dy()-1)))
+		c.drawColorEditMarker(image.Rect(bounds.Min.X-1, y-2, bounds.Max.X+1, y+3))
+	})
+	return changed, err
+}
+
+// colorEditAlphaBar creates the vertical bar to edit the alpha.
+func (c *Context) colorEditAlphaBar(state *colorEditState) (bool, error) {
+	id := c.idStack.push(idPartFromString("alpha"))
+	var changed bool
+	_, err := c.widget(id, optionNoNavigation, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+		if c.focus == id && c.pointing.pressed() {
+			y := clamp(float64(c.pointingPosition().Y-bounds.Min.Y)/float64(bounds.Dy()-1), 0, 1)
+			if a := uint8(math.Round((1 - y) * 255)); a != state.nrgba.A {
+				state.setHSV(state.h, state.s, state.v, a)
+				changed = true
+			}
+		}
+		return nil
+	}, func(bounds image.Rectangle) {
+		c.drawChecker(bounds)
+		n := state.nrgba
+		for j := range colorEditCells {
+			n.A = uint8(math.Round((1 - (float64(j)+0.5)/colorEditCells) * 255))
+			c.drawRect(colorEditCell(bounds, 0, j, 1, colorEditCells), n)
+		}
+		y := bounds.Min.Y + int(math.Round((1-float64(state.nrgba.A)/255)*float64(bounds.Dy()-1)))
+		c.drawColorEditMarker(image.Rect(bounds.Min.X-1, y-2, bounds.Max.X+1, y+3))
+	})
+	return changed, err
+}
+
+// colorEditCell returns the bounds of the cell at (i, j) when bounds is divided into xCount x yCount cells.
+func colorEditCell(bounds image.Rectangle, i, j, xCount, yCount int) image.Rectangle {
+	return image.Rect(
+		bounds.Min.X+i*bounds.Dx()/xCount,
+		bounds.Min.Y+j*bounds.Dy()/yCount,
+		bounds.Min.X+(i+1)*bounds.Dx()/xCount,
+		bounds.Min.Y+(j+1)*bounds.Dy()/yCount,
+	)
+}
+
+// drawColorEditMarker draws a marker of the current position in the square or a bar, visible on any color.
+func (c *Context) drawColorEditMarker(rect image.Rectangle) {
+	c.drawBox(rect, color.White)
+	c.drawBox(rect.Inset(-1), color.Black)
+}
+
+// drawChecker draws a checkerboard to show the transparency of a color drawn over it.
+func (c *Context) drawChecker(bounds image.Rectangle) {
+	c.drawRect(bounds, color.Gray{Y: 0xcc})
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += colorEditCheckerSize {
+		for x := bounds.Min.X; x < bounds.Max.X; x += colorEditCheckerSize {
+			if ((x-bounds.Min.X)/colorEditCheckerSize+(y-bounds.Min.Y)/colorEditCheckerSize)%2 == 0 {
+				continue
+			}
+			c.drawRect(image.Rect(x, y, x+colorEditCheckerSize, y+colorEditCheckerSize).Intersect(bounds), color.Gray{Y: 0x88})
+		}
+	}
+}
+
+// drawColorSwatch draws a swatch of the color with its hex code.
+func (c *Context) drawColorSwatch(id widgetID, bounds image.Rectangle, clr color.RGBA) {
+	n := color.NRGBAModel.Convert(clr).(color.NRGBA)
+	if n.A < 0xff {
+		c.drawChecker(bounds)
+	}
+	c.drawRect(bounds, clr)
+	if c.focus == id || c.hover == id {
+		c.drawBox(bounds, c.style().Colors[ColorButtonHover])
+	} else {
+		c.drawBox(bounds, c.style().Colors[ColorBorder])
+	}
+
+	// Draw the hex code in black or white, whichever is more visible on the color.
+	textColor := color.Color(color.White)
+	if n.A >= 0x80 && 0.299*float64(n.R)+0.587*float64(n.G)+0.114*float64(n.B) > 0x80 {
+		textColor = color.Black
+	}
+	str := colorHex(n)
+	pos := image.Pt(bounds.Min.X+(bounds.Dx()-c.textWidth(str))/2, bounds.Min.Y+(bounds.Dy()-c.lineHeight())/2)
+	c.pushClipRect(bounds)
+	c.drawText(str, pos, textColor)
+	c.popClipRect()
+}
+
+// setRGBA sets the color given outside of the widget, and updates the HSV channels from the color.
+func (s *colorEditState) setRGBA(clr color.RGBA) {
+	s.rgba = clr
+	s.nrgba = color.NRGBAModel.Convert(clr).(color.NRGBA)
+	s.updateHSV(s.nrgba)
+	s.initialized = true
+}
+
+// setNRGBA sets the color in the non-premultiplied form, and updates the HSV channels from the color.
+func (s *colorEditState) setNRGBA(clr color.NRGBA) {
+	s.nrgba = clr
+	s.updateHSV(clr)
+}
+
+// setHSV sets the color by the HSV channels and the alpha.
+func (s *colorEditState) setHSV(h, sat, v float64, a uint8) {
+	s.h, s.s, s.v = h, sat, v
+	s.nrgba = hsvToNRGBA(h, sat, v, a)
+}
+
+// updateHSV updates the HSV channels from the color, keeping the hue and the saturation if they cannot be determined.
+func (s *colorEditState) updateHSV(clr color.NRGBA) {
+	h, sat, v := nrgbaToHSV(clr)
+	if v > 0 {
+		if sat > 0 {
+			s.h = h
+		}
+		s.s = sat
+	}
+	s.v = v
+}
+
+// nrgbaToHSV returns the hue in [0, 360), and the saturation and the value in [0, 1] of the color.
+func nrgbaToHSV(clr color.NRGBA) (h, s, v float64) {
+	r, g, b := float64(clr.R)/255, float64(clr.G)/255, float64(clr.B)/255
+	maxc := max(r, g, b)
+	d := maxc - min(r, g, b)
+	v = maxc
+	if maxc > 0 {
+		s = d / maxc
+	}
+	if d == 0 {
+		return 0, s, v
+	}
+	switch maxc {
+	case r:
+		h = math.Mod((g-b)/d+6, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h * 60, s, v
+}
+
+// hsvToNRGBA returns the color of the hue in [0, 360), the saturation and the value in [0, 1], and the alpha.
+func hsvToNRGBA(h, s, v float64, a uint8) color.NRGBA {
+	f := func(n float64) uint8 {
+		k := math.Mod(n+h/60, 6)
+		return uint8(math.Round((v - v*s*max(0, min(k, 4-k, 1))) * 255))
+	}
+	return color.NRGBA{R: f(5), G: f(3), B: f(1), A: a}
+}
+
+// colorHex returns the hex code of the color like "#FF8000". The alpha is appended only if the color is translucent.
+func colorHex(clr color.NRGBA) string {
+	if clr.A == 0xff {
+		return fmt.Sprintf("#%02X%02X%02X", clr.R, clr.G, clr.B)
+	}
+	return fmt.Sprintf("#%02X%02X%02X%02X", clr.R, clr.G, clr.B, clr.A)
+}
+
+// parseColorHex parses a hex code like "#FF8000" or "#FF800080". The leading '#' is optional.
+func parseColorHex(str string) (color.NRGBA, bool) {
+	str = strings.TrimPrefix(strings.TrimSpace(str), "#")
+	if len(str) != 6 && len(str) != 8 {
+		return color.NRGBA{}, false
+	}
+	v, err := strconv.ParseUint(str, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+	if len(str) == 6 {
+		v = v<<8 | 0xff
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, true
+}
+
+func (c *container) colorEditState(id widgetID) *colorEditState {
+	if s, ok := c.colorEdits[id]; ok {
+		return s
+	}
+	if c.colorEdits == nil {
+		c.colorEdits = map[widgetID]*colorEditState{}
+	}
+	s := &colorEditState{}
+	c.colorEdits[id] = s
+	return s
+}
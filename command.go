@@ -9,63 +9,91 @@ import (
 	"iter"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
-const (
-	commandClip = 1 + iota
-	commandRect
-	commandText
-	commandIcon
-	commandDraw
-)
+// Command is a drawing command generated by the debug UI.
+//
+// A Command is one of *ClipCommand, *RectCommand, *LineCommand, *TextCommand, *IconCommand, and *DrawCommand.
+// The positions of a Command are in the UI coordinate, i.e., not multiplied by the UI scale.
+type Command interface {
+	isCommand()
+}
 
-type clipCommand struct {
-	rect image.Rectangle
+// ClipCommand is a command to set the clipping rectangle for the following commands.
+type ClipCommand struct {
+	// Rect is the clipping rectangle.
+	Rect image.Rectangle
 }
 
-type rectCommand struct {
-	rect  image.Rectangle
-	color color.Color
+// RectCommand is a command to fill a rectangle.
+type RectCommand struct {
+	// Rect is the rectangle to fill.
+	Rect image.Rectangle
+
+	// Color is the color to fill with.
+	Color color.Color
 }
 
-type textCommand struct {
-	pos   image.Point
-	color color.Color
-	str   string
+// LineCommand is a command to draw a line segment, which is 1 pixel wide in the UI coordinate.
+type LineCommand struct {
+	// From and To are the end points of the line segment.
+	From image.Point
+	To   image.Point
+
+	// Color is the color of the line segment.
+	Color color.Color
 }
 
-type iconCommand struct {
-	rect  image.Rectangle
-	icon  icon
-	color color.Color
+// TextCommand is a command to draw a text.
+type TextCommand struct {
+	// Position is the upper-left position of the text.
+	Position image.Point
+
+	// Color is the color of the text.
+	Color color.Color
+
+	// Text is the text to draw.
+	Text string
+
+	// Face is the font face to draw the text with.
+	Face text.Face
 }
 
-type drawCommand struct {
-	f func(screen *ebiten.Image)
+// IconCommand is a command to draw an icon.
+type IconCommand struct {
+	// Rect is the rectangle to draw the icon at. The icon is centered in the rectangle.
+	Rect image.Rectangle
+
+	// Icon is the icon to draw.
+	Icon Icon
+
+	// Color is the color to scale the icon's color with.
+	Color color.Color
 }
 
-type command struct {
-	typ  int
-	clip clipCommand
-	rect rectCommand
-	text textCommand
-	icon iconCommand
-	draw drawCommand
+// DrawCommand is a command to draw with an arbitrary function, created by [Context.DrawOnlyWidget].
+type DrawCommand struct {
+	// Draw is the function to draw onto the screen.
+	Draw func(screen *ebiten.Image)
 }
 
-// appendCommand adds a new command with type cmdType to the command list.
-func (c *Context) appendCommand(cmdType int) *command {
-	cmd := command{
-		typ: cmdType,
-	}
+func (*ClipCommand) isCommand() {}
+func (*RectCommand) isCommand() {}
+func (*LineCommand) isCommand() {}
+func (*TextCommand) isCommand() {}
+func (*IconCommand) isCommand() {}
+func (*DrawCommand) isCommand() {}
+
+// appendCommand adds a new command to the command list.
+func (c *Context) appendCommand(cmd Command) {
 	cnt := c.currentRootContainer()
-	cnt.commandList = append(cnt.commandList, &cmd)
-	return &cmd
+	cnt.commandList = append(cnt.commandList, cmd)
 }
 
 // commands returns a sequence of commands from all root containers.
-func (c *Context) commands() iter.Seq[*command] {
-	return func(yield func(command *command) bool) {
+func (c *Context) commands() iter.Seq[Command] {
+	return func(yield func(command Command) bool) {
 		for _, cnt := range c.rootContainers {
 			for _, cmd := range cnt.commandList {
 				if !yield(cmd) {
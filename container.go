@@ -19,11 +19,41 @@ type container struct {
 
 	// commandList is valid only for root containers.
 	// See the implementation of appendCommand which is the only place to append commands.
-	commandList []*command
+	commandList []Command
+
+	// widgetInfos is valid only for root containers.
+	widgetInfos []widgetInfo
+
+	// windowOptions is the options of a root container.
+	windowOptions option
+
+	// key is the key of a root container, i.e., the key set by SetNextKey or the title of a window.
+	// key is empty if the container cannot be persisted.
+	key string
+
+	// headerExpansions and pendingHeaderExpansions are valid only for root containers.
+	// They map the key paths of headers to whether the headers are expanded.
+	headerExpansions        map[string]bool
+	pendingHeaderExpansions map[string]bool
 
 	toggledIDs          map[widgetID]struct{}
 	textInputTextFields map[widgetID]*textinput.Field
 
+	// textSelections maps the IDs of text editing widgets to their selections with directions.
+	textSelections map[widgetID]textSelection
+
+	// tabBars maps the IDs of tab bars to their states.
+	tabBars map[widgetID]*tabBarState
+
+	// colorEdits maps the IDs of color edit widgets to their states.
+	colorEdits map[widgetID]*colorEditState
+
+	// menuItemWidth is the width of the items of a menu, i.e., the maximum width of the items in the last frame.
+	// nextMenuItemWidth is the maximum width of the items in the current frame.
+	// They are valid only for root containers of menus.
+	menuItemWidth     int
+	nextMenuItemWidth int
+
 	// dropdownCloseDelay is used for delayed closing of dropdowns
 	dropdownCloseDelay int
 
@@ -84,7 +114,7 @@ func (c *Context) currentRootContainer() *container {
 // rect is the initial size and position of the window.
 func (c *Context) Window(title string, initialBounds image.Rectangle, f func(layout ContainerLayout)) {
 	pc := caller()
-	idPart := idPartFromCaller(pc)
+	idPart := c.nextIDPart(pc)
 	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
 		if err := c.window(title, initialBounds, 0, idPart, f); err != nil {
 			return nil, err
@@ -111,6 +141,22 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 	if cnt.layout.Bounds.Dx() == 0 {
 		cnt.layout.Bounds = initialBounds
 	}
+	// Only a window with a title bar can be persisted.
+	var key string
+	if (^opt & optionNoTitle) != 0 {
+		key = title
+		if k, ok := keyFromID(id); ok {
+			key = k
+		}
+	}
+	// A modal window is not persisted, as it is centered and shown only while it is needed.
+	if (opt & optionModal) == 0 {
+		cnt.key = key
+	} else {
+		cnt.key = ""
+	}
+	cnt.windowOptions = opt
+	c.restoreWindowState(cnt)
 
 	c.pushContainer(cnt, true)
 	defer c.popContainer()
@@ -128,33 +174,38 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 	body := cnt.layout.Bounds
 	bounds := body
 
+	// dim the screen behind a modal window
+	if (opt & optionModal) != 0 {
+		c.drawRect(image.Rectangle{Max: c.screenSizeInUI()}, c.style().Colors[ColorModalDim])
+	}
+
 	// draw frame
 	collapsed := cnt.collapsed
 	if (^opt&optionNoFrame) != 0 && !collapsed {
-		c.drawFrame(bounds, colorWindowBG)
+		c.drawFrame(bounds, ColorWindowBG)
 	}
 
 	// do title bar
 	if (^opt & optionNoTitle) != 0 {
 		tr := bounds
-		tr.Max.Y = tr.Min.Y + c.style().titleHeight
+		tr.Max.Y = tr.Min.Y + c.titleHeight()
 		if !collapsed {
-			c.drawFrame(tr, colorTitleBG)
+			c.drawFrame(tr, ColorTitleBG)
 		} else {
-			c.drawFrame(tr, colorTitleBGTransparent)
+			c.drawFrame(tr, ColorTitleBGTransparent)
 		}
 
 		// do title text
 		if (^opt & optionNoTitle) != 0 {
 			titleID := id.push(idPartFromString("title"))
-			r := image.Rect(tr.Min.X+tr.Dy()-c.style().padding, tr.Min.Y, tr.Max.X, tr.Max.Y)
+			r := image.Rect(tr.Min.X+tr.Dy()-c.style().Padding, tr.Min.Y, tr.Max.X, tr.Max.Y)
 			_ = c.widgetWithBounds(titleID, opt, r, func(bounds image.Rectangle, wasFocused bool) EventHandler {
 				if titleID == c.focus && c.pointing.pressed() {
 					b := cnt.layout.Bounds.Add(c.pointingDelta())
 					if c.screenWidth > 0 {
 						maxX := b.Max.X
-						if maxX >= c.screenWidth/c.Scale() {
-							b = b.Add(image.Pt(c.screenWidth/c.Scale()-maxX, 0))
+						if maxX >= c.screenSizeInUI().X {
+							b = b.Add(image.Pt(c.screenSizeInUI().X-maxX, 0))
 						}
 					}
 					if b.Min.X < 0 {
@@ -162,8 +213,8 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 					}
 					if c.screenHeight > 0 {
 						maxY := b.Min.Y + tr.Dy()
-						if maxY >= c.screenHeight/c.Scale()-c.style().padding {
-							b = b.Add(image.Pt(0, c.screenHeight/c.Scale()-maxY))
+						if maxY >= c.screenSizeInUI().Y-c.style().Padding {
+							b = b.Add(image.Pt(0, c.screenSizeInUI().Y-maxY))
 						}
 					}
 					if b.Min.Y < 0 {
@@ -174,7 +225,8 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 				body.Min.Y += tr.Dy()
 				return nil
 			}, func(bounds image.Rectangle) {
-				c.drawWidgetText(title, r, colorTitleText, opt)
+				c.setDrawingWidgetKey(key)
+				c.drawWidgetText(title, r, ColorTitleText, opt)
 			})
 		}
 
@@ -188,11 +240,11 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 				}
 				return nil
 			}, func(bounds image.Rectangle) {
-				icon := iconExpanded
+				icon := IconExpanded
 				if collapsed {
-					icon = iconCollapsed
+					icon = IconCollapsed
 				}
-				c.drawIcon(icon, r, c.style().colors[colorTitleText])
+				c.drawIcon(icon, r, c.style().Colors[ColorTitleText])
 			})
 		}
 	}
@@ -212,13 +264,13 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 
 	// do `resize` handle
 	if (^opt & optionNoResize) != 0 {
-		sz := c.style().titleHeight
+		sz := c.titleHeight()
 		resizeID := id.push(idPartFromString("resize"))
 		r := image.Rect(bounds.Max.X-sz, bounds.Max.Y-sz, bounds.Max.X, bounds.Max.Y)
 		_ = c.widgetWithBounds(resizeID, 0, r, func(bounds image.Rectangle, wasFocused bool) EventHandler {
 			if resizeID == c.focus && c.pointing.pressed() {
-				cnt.layout.Bounds.Max.X = min(cnt.layout.Bounds.Min.X+max(96, cnt.layout.Bounds.Dx()+c.pointingDelta().X), c.screenWidth/c.Scale())
-				cnt.layout.Bounds.Max.Y = min(cnt.layout.Bounds.Min.Y+max(64, cnt.layout.Bounds.Dy()+c.pointingDelta().Y), c.screenHeight/c.Scale())
+				cnt.layout.Bounds.Max.X = min(cnt.layout.Bounds.Min.X+max(96, cnt.layout.Bounds.Dx()+c.pointingDelta().X), c.screenSizeInUI().X)
+				cnt.layout.Bounds.Max.Y = min(cnt.layout.Bounds.Min.Y+max(64, cnt.layout.Bounds.Dy()+c.pointingDelta().Y), c.screenSizeInUI().Y)
 			}
 			return nil
 		}, nil)
@@ -239,10 +291,19 @@ func (c *Context) doWindow(title string, initialBounds image.Rectangle, opt opti
 	if (opt&optionPopup) != 0 && c.pointing.justPressed() && c.hoveringRootContainer() != cnt {
 		cnt.open = false
 	}
+	// close if this is the frontmost popup window and the gamepad's B button was pressed
+	if (opt&optionPopup) != 0 && c.navCanceled() && c.frontRootContainer() == cnt {
+		cnt.open = false
+	}
 
 	c.pushClipRect(cnt.layout.BodyBounds)
 	defer c.popClipRect()
 
+	if key != "" {
+		c.pushKeyPath(key)
+		defer c.popKeyPath()
+	}
+
 	f(c.currentContainer().layout)
 
 	return nil
@@ -253,13 +314,17 @@ type PopupID widgetID
 
 // OpenPopup opens a popup window at the current pointing position.
 func (c *Context) OpenPopup(popupID PopupID) {
+	c.openPopup(widgetID(popupID), c.pointingPosition())
+}
+
+// openPopup opens the popup window at the position.
+func (c *Context) openPopup(id widgetID, pos image.Point) {
 	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
-		cnt := c.container(widgetID(popupID), 0)
-		// Position at pointing cursor, open and bring-to-front.
-		pt := c.pointingPosition()
+		cnt := c.container(id, 0)
+		// Position at pos, open and bring-to-front.
 		cnt.layout.Bounds = image.Rectangle{
-			Min: pt,
-			Max: pt.Add(image.Pt(1, 1)),
+			Min: pos,
+			Max: pos.Add(image.Pt(1, 1)),
 		}
 		cnt.open = true
 		c.bringToFront(cnt)
@@ -283,11 +348,10 @@ func (c *Context) ClosePopup(popupID PopupID) {
 // To show the popup window, call OpenPopup with the PopupID returned by this function.
 func (c *Context) Popup(f func(layout ContainerLayout, popupID PopupID)) PopupID {
 	pc := caller()
-	idPart := idPartFromCaller(pc)
+	idPart := c.nextIDPart(pc)
 	id := c.idStack.push(idPart)
 	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
-		opt := optionPopup | optionAutoSize | optionNoResize | optionNoScroll | optionNoTitle | optionClosed
-		if err := c.window("", image.Rectangle{}, opt, idPart, func(layout ContainerLayout) {
+		if err := c.popup(idPart, func(layout ContainerLayout) {
 			f(layout, PopupID(id))
 		}); err != nil {
 			return nil, err
@@ -297,6 +361,65 @@ func (c *Context) Popup(f func(layout ContainerLayout, popupID PopupID)) PopupID
 	return PopupID(id)
 }
 
+func (c *Context) popup(idPart string, f func(layout ContainerLayout)) error {
+	opt := optionPopup | optionAutoSize | optionNoResize | optionNoScroll | optionNoTitle | optionClosed
+	return c.window("", image.Rectangle{}, opt, idPart, f)
+}
+
+// popupPosition returns the position of a root container with the size placed at p, moved to keep it inside the screen.
+//
+// If the root container overflows the right edge of the screen, it is moved to end at flip.X.
+// If the root container overflows the bottom edge of the screen, it is moved to end at flip.Y.
+func popupPosition(p image.Point, flip image.Point, size image.Point, screen image.Point) image.Point {
+	if screen.X > 0 && screen.Y > 0 {
+		if p.X+size.X > screen.X {
+			p.X = flip.X - size.X
+		}
+		if p.Y+size.Y > screen.Y {
+			p.Y = flip.Y - size.Y
+		}
+	}
+	p.X = max(p.X, 0)
+	p.Y = max(p.Y, 0)
+	return p
+}
+
+// fittingWindowMeasuringSize is the size of a root container laid out to measure its content by fittingWindow.
+const fittingWindowMeasuringSize = 480
+
+// fittingWindow creates a root container resized to fit its content.
+//
+// position returns the position of the root container from its size.
+// A new root container, or a root container whose size is reset, is laid out outside the screen without being shown
+// to measure its size. This also prevents the input in the current frame from reaching its content.
+func (c *Context) fittingWindow(cnt *container, title string, opt option, idPart string, position func(size image.Point) image.Point, f func(layout ContainerLayout)) error {
+	size := cnt.layout.Bounds.Size()
+	measuring := size == (image.Point{})
+	var p image.Point
+	if measuring {
+		size = image.Pt(fittingWindowMeasuringSize, fittingWindowMeasuringSize)
+		p = image.Pt(-2*fittingWindowMeasuringSize, -2*fittingWindowMeasuringSize)
+	} else {
+		p = position(size)
+	}
+	cnt.layout.Bounds = image.Rectangle{
+		Min: p,
+		Max: p.Add(size),
+	}
+
+	lastContentSize := cnt.layout.ContentSize
+	if err := c.window(title, image.Rectangle{}, opt|optionAutoSize, idPart, f); err != nil {
+		return err
+	}
+	if measuring {
+		// The auto-sizing uses the content size of the last frame. Fit the size to the measured content size.
+		cnt.layout.Bounds.Max = cnt.layout.Bounds.Max.Add(cnt.layout.ContentSize.Sub(lastContentSize))
+		cnt.commandList = slices.Delete(cnt.commandList, 0, len(cnt.commandList))
+		cnt.widgetInfos = slices.Delete(cnt.widgetInfos, 0, len(cnt.widgetInfos))
+	}
+	return nil
+}
+
 func (c *Context) pushContainer(cnt *container, root bool) {
 	if !root && len(c.containerStack) > 0 {
 		cnt.parent = c.containerStack[len(c.containerStack)-1]
@@ -308,7 +431,7 @@ func (c *Context) pushContainerBodyLayout(cnt *container, body image.Rectangle,
 	if (^opt & optionNoScroll) != 0 {
 		body = c.scrollbars(cnt, body)
 	}
-	if err := c.pushLayout(body.Inset(c.style().padding), cnt.layout.ScrollOffset, opt&optionAutoSize != 0); err != nil {
+	if err := c.pushLayout(body.Inset(c.style().Padding), cnt.layout.ScrollOffset, opt&optionAutoSize != 0); err != nil {
 		return err
 	}
 	cnt.layout.BodyBounds = body
@@ -369,16 +492,53 @@ func (c *Context) bringToFront(cnt *container) {
 	c.rootContainers = append(c.rootContainers, cnt)
 }
 
+func (c *Context) sendToBack(cnt *container) {
+	idx := slices.IndexFunc(c.rootContainers, func(c *container) bool {
+		return c == cnt
+	})
+	if idx <= 0 {
+		return
+	}
+	c.rootContainers = slices.Delete(c.rootContainers, idx, idx+1)
+	c.rootContainers = slices.Insert(c.rootContainers, 0, cnt)
+}
+
+// frontRootContainer returns the frontmost open root container except tooltips, or nil if there is no such container.
+func (c *Context) frontRootContainer() *container {
+	for i := len(c.rootContainers) - 1; i >= 0; i-- {
+		if cnt := c.rootContainers[i]; cnt.open && cnt.windowOptions&optionNoInteract == 0 {
+			return cnt
+		}
+	}
+	return nil
+}
+
+// hoveringRootContainer returns the frontmost root container at the pointing position, or nil if there is no such container.
+//
+// The root containers behind an open modal window are never hovered.
 func (c *Context) hoveringRootContainer() *container {
 	p := c.pointingPosition()
 	for i := len(c.rootContainers) - 1; i >= 0; i-- {
 		cnt := c.rootContainers[i]
-		if !cnt.open {
+		if !cnt.open || cnt.windowOptions&optionNoInteract != 0 {
 			continue
 		}
 		if p.In(cnt.layout.Bounds) {
 			return cnt
 		}
+		if cnt.windowOptions&optionModal != 0 {
+			return nil
+		}
+	}
+	return nil
+}
+
+// modalRootContainer returns the frontmost open modal window, or nil if there is no such window.
+func (c *Context) modalRootContainer() *container {
+	for i := len(c.rootContainers) - 1; i >= 0; i-- {
+		if cnt := c.rootContainers[i]; cnt.open && cnt.windowOptions&optionModal != 0 {
+			return cnt
+		}
 	}
 	return nil
 }
@@ -8,8 +8,9 @@ import (
 	"image"
 	"maps"
 	"slices"
+	"time"
 
-	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
 func clamp[T int | float64](x, a, b T) T {
@@ -18,9 +19,18 @@ func clamp[T int | float64](x, a, b T) T {
 
 // Context is the main context for the debug UI.
 type Context struct {
+	input    InputSource
 	pointing pointing
+	keyboard keyboard
+	gamepad  gamepad
+
+	// scale is the scale set by SetScale. If scale is 0, deviceScaleFactor is used.
+	scale             float64
+	deviceScaleFactor float64
+
+	// doubleClickInterval is the interval set by SetDoubleClickInterval. If doubleClickInterval is 0, defaultDoubleClickInterval is used.
+	doubleClickInterval time.Duration
 
-	scaleMinus1   int
 	hover         widgetID
 	focus         widgetID
 	currentID     widgetID
@@ -29,6 +39,14 @@ type Context struct {
 	numberEditBuf string
 	numberEdit    widgetID
 
+	// navFocus is the widget with the navigation focus moved by the keyboard.
+	// The focus ring is drawn only when navFocusVisible is true.
+	navFocus        widgetID
+	navFocusVisible bool
+
+	// focusables is a list of the widgets that can get the navigation focus in the current frame.
+	focusables []focusable
+
 	idStack widgetID
 
 	// idToContainer maps widget IDs to containers.
@@ -49,8 +67,75 @@ type Context struct {
 	clipStack   []image.Rectangle
 	layoutStack []layout
 
+	// face is the font face set by SetFontFace. If face is nil, the default font face is used.
+	face text.Face
+
+	// theme is the theme set by SetTheme. If theme is nil, the default theme is used.
+	theme *Theme
+
+	// styleStack is a stack of styles pushed by PushStyle.
+	styleStack []*Theme
+
+	editHistory editHistory
+
+	// tabs is the list of the tabs added to the tab bar being created. tabs is nil outside of TabBar.
+	tabs *[]tab
+
+	// tabSelections maps the IDs of tab bars to the indices of the tabs selected by SelectTab.
+	// They are applied when the tab bars are created next time.
+	tabSelections map[widgetID]int
+
+	// openMenus is the IDs of the open menus.
+	// The first one is a menu of a menu bar or a context menu, and the others are its submenus in order.
+	openMenus []widgetID
+
+	// openMenuBarID is the ID of the menu bar whose menu is open.
+	openMenuBarID widgetID
+
+	// contextMenuPosition is the position where the open context menu was opened.
+	contextMenuPosition image.Point
+
+	// currentMenuBar is the menu bar being created. currentMenuBar is nil outside of MenuBar and MainMenuBar.
+	currentMenuBar *menuBar
+
+	// menuLevel is the number of the menus being created. menuLevel is 0 outside of menus.
+	menuLevel int
+
+	// menuPressed reports whether a menu handled the press in the current frame.
+	menuPressed bool
+
+	// tooltipID is the ID of the widget hovered for tooltipTicks ticks.
+	tooltipID    widgetID
+	tooltipTicks int
+
+	// tooltipContent is the content of the tooltip to show in the current frame.
+	tooltipContent func(layout ContainerLayout)
+
+	// perf is the statistics for PerfWindow. perf is nil until PerfWindow is called.
+	perf *perfStats
+
+	// clipboard is the clipboard set by SetClipboard. If clipboard is nil, memoryClipboard is used.
+	clipboard       Clipboard
+	memoryClipboard memoryClipboard
+
 	lastPointingPos image.Point
 
+	// nextKey is the key for the next window or widget set by SetNextKey.
+	nextKey string
+
+	// keyPath is a stack of the ID paths of the current window, panels, headers and ID scopes.
+	// Each item is the keys from the window joined with '/'.
+	// If a window or a header doesn't have a key, its title or label is used instead.
+	keyPath []string
+
+	// pendingWindowStates maps window keys to the states loaded by LoadState but not applied yet.
+	pendingWindowStates map[string]*windowState
+
+	// restoredZOrders maps root containers restored in the current frame to their loaded z-orders.
+	restoredZOrders map[*container]int
+
+	drawingWidgetInfo *widgetInfo
+
 	screenWidth  int
 	screenHeight int
 
@@ -77,7 +162,10 @@ func (c *Context) update(f func(ctx *Context) error) (inputCapturingState InputC
 		return 0, c.err
 	}
 
-	c.pointing.update()
+	c.updateDeviceScaleFactor()
+	c.pointing.update(c.inputSource(), c.doubleClickTicks())
+	c.keyboard.update(c.inputSource())
+	c.gamepad.update(c.inputSource())
 
 	c.beginUpdate()
 	defer func() {
@@ -89,6 +177,7 @@ func (c *Context) update(f func(ctx *Context) error) (inputCapturingState InputC
 	if err := f(c); err != nil {
 		return 0, err
 	}
+	c.drawTooltip()
 	if c.err != nil {
 		return 0, c.err
 	}
@@ -96,6 +185,10 @@ func (c *Context) update(f func(ctx *Context) error) (inputCapturingState InputC
 	// Check whether the cursor is on any of the root containers.
 	pt := c.pointingPosition()
 	for _, cnt := range c.rootContainers {
+		// A tooltip doesn't capture the pointing device.
+		if cnt.windowOptions&optionNoInteract != 0 {
+			continue
+		}
 		bounds := cnt.layout.Bounds
 		if cnt.collapsed {
 			bounds.Max.Y = cnt.layout.BodyBounds.Min.Y
@@ -105,10 +198,15 @@ func (c *Context) update(f func(ctx *Context) error) (inputCapturingState InputC
 		}
 	}
 
-	// Check whether there is a focused widget like a text field.
-	if c.focus != (widgetID{}) {
+	// Check whether there is a focused widget like a text field, or a widget with the navigation focus.
+	if c.focus != (widgetID{}) || c.navFocusVisible {
 		inputCapturingState |= InputCapturingStateFocus
 	}
+
+	// A modal window captures all the input.
+	if c.modalRootContainer() != nil {
+		inputCapturingState |= InputCapturingStateHover | InputCapturingStateFocus
+	}
 	return inputCapturingState, nil
 }
 
@@ -116,11 +214,19 @@ func (c *Context) beginUpdate() {
 	for _, cnt := range c.idToContainer {
 		cnt.used = false
 	}
+	// Record the cost of the last frame before clearing the commands.
+	if c.perf != nil {
+		c.perf.recordContainers(c.rootContainers)
+	}
 	for _, cnt := range c.rootContainers {
 		cnt.commandList = slices.Delete(cnt.commandList, 0, len(cnt.commandList))
+		cnt.widgetInfos = slices.Delete(cnt.widgetInfos, 0, len(cnt.widgetInfos))
 	}
 	c.scrollTarget = nil
 	c.currentID = widgetID{}
+	c.nextKey = ""
+	c.menuPressed = false
+	c.focusables = slices.Delete(c.focusables, 0, len(c.focusables))
 }
 
 func (c *Context) endUpdate() error {
@@ -137,14 +243,20 @@ func (c *Context) endUpdate() error {
 	if len(c.layoutStack) > 0 {
 		return errors.New("debugui: layout stack must be empty")
 	}
+	if len(c.styleStack) > 0 {
+		return errors.New("debugui: style stack must be empty")
+	}
 
 	// handle scroll input
 	if c.scrollTarget != nil {
-		wx, wy := ebiten.Wheel()
+		wx, wy := c.pointing.wheel()
 		c.scrollTarget.layout.ScrollOffset.X += int(wx * -30)
 		c.scrollTarget.layout.ScrollOffset.Y += int(wy * -30)
 	}
 
+	c.updateNavigation()
+	c.updateEditHistory()
+
 	// unset focus if focus id was not touched this frame
 	if !c.keepFocus {
 		c.focus = widgetID{}
@@ -160,6 +272,9 @@ func (c *Context) endUpdate() error {
 		}
 	}
 
+	c.updateMenus()
+	c.restoreZOrders()
+
 	// reset input state
 	c.lastPointingPos = c.pointingPosition()
 
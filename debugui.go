@@ -8,6 +8,17 @@ import "github.com/hajimehoshi/ebiten/v2"
 // DebugUI is a debug UI.
 //
 // The zero value for DebugUI is ready to use.
+//
+// A debug UI can be operated with a keyboard. Tab and Shift+Tab move the focus across the widgets of the frontmost window.
+// Space and Enter activate the focused button, checkbox or header, and Enter starts editing the focused text field.
+// Arrow keys adjust the focused slider, number field or dropdown. Escape hides the focus.
+//
+// A debug UI can also be operated with a gamepad with the standard layout. The D-pad moves the focus to the nearest widget
+// in the direction, and A activates the focused widget. B closes popups and dropdowns.
+// The left stick adjusts the focused slider, and the shoulder buttons cycle the windows.
+//
+// The navigation starts only while the pointer is on a window, a widget is focused or a modal window is open,
+// so the game can still use Tab, the D-pad and the shoulder buttons.
 type DebugUI struct {
 	ctx Context
 }
@@ -16,10 +27,11 @@ type DebugUI struct {
 type InputCapturingState int
 
 const (
-	// InputCapturingStateHover indicates that a pointing device is hovering over a widget.
+	// InputCapturingStateHover indicates that a pointing device is hovering over a widget, or a modal window is open.
 	InputCapturingStateHover InputCapturingState = 1 << iota
 
-	// InputCapturingStateFocus indicates that a widget like a text field is focused.
+	// InputCapturingStateFocus indicates that a widget like a text field is focused,
+	// a widget has the keyboard navigation focus, or a modal window is open.
 	InputCapturingStateFocus
 )
 
@@ -37,10 +49,17 @@ func (d *DebugUI) Update(f func(ctx *Context) error) (InputCapturingState, error
 	return inputCapturingState, nil
 }
 
+// SetInputSource sets the source of input for the debug UI.
+//
+// If src is nil, the debug UI reads input from Ebitengine directly. This is the default behavior.
+func (d *DebugUI) SetInputSource(src InputSource) {
+	d.ctx.input = src
+}
+
 // Draw draws the debug UI.
 //
 // Draw should be called once in the game's Draw function.
 func (d *DebugUI) Draw(screen *ebiten.Image) {
-	d.ctx.draw(screen)
+	d.Render(&EbitenRenderer{Target: screen})
 	d.ctx.screenWidth, d.ctx.screenHeight = screen.Bounds().Dx(), screen.Bounds().Dy()
 }
@@ -4,11 +4,24 @@
 package debugui_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"image"
+	"image/color"
+	"io"
+	"iter"
+	"math"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/ebitengine/debugui"
+	"github.com/ebitengine/debugui/debuguitest"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
 )
 
 func TestMultipleIDPartFromCallersInForLoop(t *testing.T) {
@@ -138,3 +151,1047 @@ func TestUnusedContainer(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+type testInputSource struct {
+	x, y     int
+	pressed  bool
+	touching bool
+}
+
+func (t *testInputSource) CursorPosition() (x, y int) {
+	return t.x, t.y
+}
+
+func (t *testInputSource) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return button == ebiten.MouseButtonLeft && t.pressed
+}
+
+func (t *testInputSource) AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID {
+	if t.touching {
+		touches = append(touches, 1)
+	}
+	return touches
+}
+
+func (t *testInputSource) TouchPosition(id ebiten.TouchID) (x, y int) {
+	return t.x, t.y
+}
+
+func (t *testInputSource) Wheel() (xoff, yoff float64) {
+	return 0, 0
+}
+
+func (t *testInputSource) IsKeyPressed(key ebiten.Key) bool {
+	return false
+}
+
+func (t *testInputSource) AppendInputChars(runes []rune) []rune {
+	return runes
+}
+
+func TestInputSource(t *testing.T) {
+	var d debugui.DebugUI
+	input := &testInputSource{}
+	d.SetInputSource(input)
+
+	var count int
+	update := func() {
+		if _, err := d.Update(func(ctx *debugui.Context) error {
+			ctx.SetScale(1)
+			ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+				ctx.Button("Button").On(func() {
+					count++
+				})
+			})
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The button is at the top-left corner of the window body.
+	input.x, input.y = 20, 35
+	update()
+	input.pressed = true
+	update()
+	update()
+	input.pressed = false
+	update()
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+
+	// Pressing outside the button doesn't fire the event.
+	input.x, input.y = 150, 150
+	update()
+	input.pressed = true
+	update()
+	input.pressed = false
+	update()
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+}
+
+func TestLongPress(t *testing.T) {
+	// A long press is detected even when the TPS is synchronized with the FPS.
+	ebiten.SetTPS(ebiten.SyncWithFPS)
+	defer ebiten.SetTPS(ebiten.DefaultTPS)
+
+	var d debugui.DebugUI
+	input := &testInputSource{}
+	d.SetInputSource(input)
+
+	var count int
+	update := func() {
+		if _, err := d.Update(func(ctx *debugui.Context) error {
+			ctx.SetScale(1)
+			ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+				ctx.Button("Button")
+				ctx.SecondaryClicked().On(func() {
+					count++
+				})
+			})
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The button is at the top-left corner of the window body.
+	input.x, input.y = 20, 35
+	input.touching = true
+	for range 60 {
+		update()
+	}
+	input.touching = false
+	update()
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+
+	// A touch moving away is not a long press.
+	input.touching = true
+	update()
+	input.x += 20
+	for range 60 {
+		update()
+	}
+	input.touching = false
+	update()
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+}
+
+type commandRecorder struct {
+	commands []debugui.Command
+}
+
+func (c *commandRecorder) Render(commands iter.Seq[debugui.Command], scale float64) {
+	c.commands = slices.Collect(commands)
+}
+
+func TestRenderer(t *testing.T) {
+	var d debugui.DebugUI
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		ctx.SetScale(1)
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Button("Button")
+		})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var r commandRecorder
+	d.Render(&r)
+	var texts []string
+	for _, cmd := range r.commands {
+		if cmd, ok := cmd.(*debugui.TextCommand); ok {
+			texts = append(texts, cmd.Text)
+		}
+	}
+	if got, want := texts, []string{"Window", "Button"}; !slices.Equal(got, want) {
+		t.Errorf("texts: got: %q, want: %q", got, want)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, 300, 300))
+	d.Render(&debugui.ImageRenderer{Target: dst})
+	// The window background.
+	if got, want := dst.RGBAAt(2, 100), (color.RGBA{45, 45, 45, 230}); got != want {
+		t.Errorf("dst.At(2, 100): got: %v, want: %v", got, want)
+	}
+	// The button.
+	if got, want := dst.RGBAAt(8, 31), (color.RGBA{75, 75, 75, 255}); got != want {
+		t.Errorf("dst.At(8, 31): got: %v, want: %v", got, want)
+	}
+	// Outside the window.
+	if got, want := dst.RGBAAt(250, 250), (color.RGBA{}); got != want {
+		t.Errorf("dst.At(250, 250): got: %v, want: %v", got, want)
+	}
+}
+
+func TestSaveAndLoadState(t *testing.T) {
+	f := func(ctx *debugui.Context) error {
+		ctx.Window("A", image.Rect(10, 10, 160, 160), func(layout debugui.ContainerLayout) {
+			ctx.Header("Header", false, func() {
+				ctx.Text("Content")
+			})
+		})
+		ctx.Window("B", image.Rect(100, 100, 250, 250), func(layout debugui.ContainerLayout) {
+			ctx.Text("B's Content")
+		})
+		return nil
+	}
+
+	ui := debuguitest.New(f)
+	if err := ui.DragWindow("A", 20, 30); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.ClickOn("Header"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Content"); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := ui.DebugUI().SaveState(&buf); err != nil {
+		t.Fatal(err)
+	}
+	saved := buf.String()
+
+	ui2 := debuguitest.New(f)
+	if err := ui2.DebugUI().LoadState(strings.NewReader(saved)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui2.Update(); err != nil {
+		t.Fatal(err)
+	}
+	a, err := ui2.Widget("A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a1, err := ui.Widget("A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := a.Bounds, a1.Bounds; got != want {
+		t.Errorf("A's title bounds: got: %v, want: %v", got, want)
+	}
+	if _, err := ui2.Widget("Content"); err != nil {
+		t.Error(err)
+	}
+	var labels []string
+	for w := range ui2.DebugUI().Widgets() {
+		if w.Label != "" {
+			labels = append(labels, w.Label)
+		}
+	}
+	// A is in front of B.
+	if got, want := labels[len(labels)-1], "Content"; got != want {
+		t.Errorf("the frontmost label: got: %q, want: %q", got, want)
+	}
+
+	buf.Reset()
+	if err := ui2.DebugUI().SaveState(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), saved; got != want {
+		t.Errorf("state: got: %s, want: %s", got, want)
+	}
+}
+
+func TestLoadInvalidState(t *testing.T) {
+	var d debugui.DebugUI
+	if err := d.LoadState(strings.NewReader(`{"version": 0}`)); err == nil {
+		t.Error("LoadState must return an error for an unsupported version")
+	}
+	if err := d.LoadState(strings.NewReader(`{`)); err == nil {
+		t.Error("LoadState must return an error for broken JSON")
+	}
+	if err := d.LoadState(strings.NewReader(`{"version": 1, "windows": [{"key": "A", "bounds": {"width": 0, "height": 100}}]}`)); err == nil {
+		t.Error("LoadState must return an error for empty bounds")
+	}
+	if err := d.LoadState(strings.NewReader(`{"version": 1, "windows": [{"key": "A", "bounds": {"width": 100, "height": 100}}, {"key": "A", "bounds": {"width": 100, "height": 100}}]}`)); err == nil {
+		t.Error("LoadState must return an error for duplicate keys")
+	}
+}
+
+func TestSaveStateDuplicateKeys(t *testing.T) {
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(10, 10, 160, 160), func(layout debugui.ContainerLayout) {})
+		ctx.Window("Window", image.Rect(100, 100, 250, 250), func(layout debugui.ContainerLayout) {})
+		return nil
+	})
+	if err := ui.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.DebugUI().SaveState(io.Discard); err == nil {
+		t.Error("SaveState must return an error for windows with the same key")
+	}
+}
+
+func TestKey(t *testing.T) {
+	var checked bool
+	var useAnotherLine bool
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.SetNextKey("demo")
+		ctx.Window("Demo Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			content := func() {
+				ctx.SetNextKey("hi-res")
+				ctx.Checkbox(&checked, "Hi-Res")
+				ctx.Button("OK")
+			}
+			// A keyed widget is identified by its key regardless of its call location.
+			ctx.SetNextKey("config")
+			if useAnotherLine {
+				ctx.Header("Game Config (moved)", true, content)
+			} else {
+				ctx.Header("Game Config", true, content)
+			}
+		})
+		return nil
+	})
+	if err := ui.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	for w := range ui.DebugUI().Widgets() {
+		if w.Path != "" {
+			paths = append(paths, w.Path)
+		}
+	}
+	if got, want := paths, []string{"demo", "demo/config", "demo/config/hi-res", "demo/config/OK"}; !slices.Equal(got, want) {
+		t.Errorf("paths: got: %q, want: %q", got, want)
+	}
+
+	if err := ui.ClickOn("demo/config"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("demo/config/hi-res"); err == nil {
+		t.Errorf("the header must be collapsed")
+	}
+	useAnotherLine = true
+	if err := ui.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("demo/config/hi-res"); err == nil {
+		t.Errorf("the header must be still collapsed after the call location changes")
+	}
+}
+
+func TestKeyInLoopAndIDScope(t *testing.T) {
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Loop(2, func(i int) {
+				ctx.Button("Button")
+			})
+			// The key is consumed by the scope, not by the widget in it.
+			ctx.SetNextKey("scope")
+			ctx.IDScope("Player", func() {
+				ctx.Button("OK")
+			})
+			ctx.SetNextKey("ok")
+			ctx.Button("OK")
+		})
+		return nil
+	})
+	if err := ui.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	for w := range ui.DebugUI().Widgets() {
+		if w.Path != "" {
+			paths = append(paths, w.Path)
+		}
+	}
+	if got, want := paths, []string{"Window", "Window/0/Button", "Window/1/Button", "Window/Player/OK", "Window/ok"}; !slices.Equal(got, want) {
+		t.Errorf("paths: got: %q, want: %q", got, want)
+	}
+
+	if _, err := ui.Widget("Button"); err == nil {
+		t.Error("Widget must return an error for an ambiguous label")
+	}
+	if _, err := ui.Widget("Window/1/Button"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInvalidKey(t *testing.T) {
+	var d debugui.DebugUI
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		ctx.SetNextKey("a/b")
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {})
+		return nil
+	}); err == nil {
+		t.Error("Update must return an error for a key containing '/'")
+	}
+}
+
+func TestTheme(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+
+	var d debugui.DebugUI
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		theme := debugui.DefaultTheme()
+		theme.Colors[debugui.ColorText] = red
+		ctx.SetTheme(theme)
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Text("Red")
+			style := ctx.Theme()
+			style.Colors[debugui.ColorText] = blue
+			ctx.PushStyle(style)
+			ctx.Text("Blue")
+			ctx.PopStyle()
+			ctx.Text("Red Again")
+		})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var r commandRecorder
+	d.Render(&r)
+	colors := map[string]color.Color{}
+	for _, cmd := range r.commands {
+		if cmd, ok := cmd.(*debugui.TextCommand); ok {
+			colors[cmd.Text] = cmd.Color
+		}
+	}
+	for text, want := range map[string]color.Color{
+		"Red":       red,
+		"Blue":      blue,
+		"Red Again": red,
+	} {
+		if got := colors[text]; got != want {
+			t.Errorf("color of %q: got: %v, want: %v", text, got, want)
+		}
+	}
+}
+
+func TestPopStyleWithoutPush(t *testing.T) {
+	var d debugui.DebugUI
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.PopStyle()
+		})
+		return nil
+	}); err == nil {
+		t.Error("Update must return an error for PopStyle without PushStyle")
+	}
+}
+
+func TestUnbalancedStyleStack(t *testing.T) {
+	var d debugui.DebugUI
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		ctx.PushStyle(ctx.Theme())
+		return nil
+	}); err == nil {
+		t.Error("Update must return an error when the style stack is not empty")
+	}
+}
+
+func TestThemeJSON(t *testing.T) {
+	for _, theme := range []*debugui.Theme{
+		debugui.DarkTheme(),
+		debugui.LightTheme(),
+		debugui.HighContrastTheme(),
+	} {
+		data, err := json.Marshal(theme)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got debugui.Theme
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != *theme {
+			t.Errorf("got: %v, want: %v", got, *theme)
+		}
+	}
+
+	got := debugui.DefaultTheme()
+	if err := json.Unmarshal([]byte(`{"padding": 8, "colors": {"text": "#ff0000", "windowBG": "#00ff0080"}}`), got); err != nil {
+		t.Fatal(err)
+	}
+	want := debugui.DefaultTheme()
+	want.Padding = 8
+	want.Colors[debugui.ColorText] = color.RGBA{0xff, 0, 0, 0xff}
+	want.Colors[debugui.ColorWindowBG] = color.RGBA{0, 0x80, 0, 0x80}
+	if *got != *want {
+		t.Errorf("got: %v, want: %v", *got, *want)
+	}
+
+	for _, data := range []string{
+		`{"colors": {"unknown": "#ffffff"}}`,
+		`{"colors": {"text": "#fff"}}`,
+		`{"colors": {"text": "ffffff"}}`,
+		`{"colors": {"text": "#gggggg"}}`,
+		`{"defaultHeight": 0}`,
+		`{"padding": -5}`,
+		`{"scrollbarSize": -1}`,
+	} {
+		theme := debugui.DefaultTheme()
+		if err := json.Unmarshal([]byte(data), theme); err == nil {
+			t.Errorf("json.Unmarshal(%s) must return an error", data)
+		}
+		if got, want := *theme, *debugui.DefaultTheme(); got != want {
+			t.Errorf("json.Unmarshal(%s) must not modify the theme: got: %v, want: %v", data, got, want)
+		}
+	}
+}
+
+func TestThemeEditorWindow(t *testing.T) {
+	var theme *debugui.Theme
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.SetEditHistoryEnabled(true)
+		ctx.ThemeEditorWindow()
+		theme = ctx.Theme()
+		return nil
+	})
+
+	if err := ui.ClickOn("Light"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := *theme, *debugui.LightTheme(); got != want {
+		t.Errorf("theme: got: %v, want: %v", got, want)
+	}
+
+	// Make the text color transparent with the alpha slider.
+	if err := ui.ClickOn("Theme Editor/Colors/text"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.MoveTo("Theme Editor/Colors/text/A"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Drag(-1000, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := theme.Colors[debugui.ColorText], (color.RGBA{}); got != want {
+		t.Errorf("text color: got: %v, want: %v", got, want)
+	}
+
+	// Undo the edit by the slider, and redo it.
+	if err := ui.MoveToPosition(500, 500); err != nil {
+		t.Fatal(err)
+	}
+	ui.Input().SetKeyPressed(ebiten.KeyControlLeft, true)
+	if err := ui.PressKey(ebiten.KeyZ); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := theme.Colors[debugui.ColorText], debugui.LightTheme().Colors[debugui.ColorText]; got != want {
+		t.Errorf("text color after undo: got: %v, want: %v", got, want)
+	}
+	if err := ui.PressKey(ebiten.KeyY); err != nil {
+		t.Fatal(err)
+	}
+	ui.Input().SetKeyPressed(ebiten.KeyControlLeft, false)
+	if got, want := theme.Colors[debugui.ColorText], (color.RGBA{}); got != want {
+		t.Errorf("text color after redo: got: %v, want: %v", got, want)
+	}
+
+	// Export the theme to the clipboard.
+	var clipboard testClipboard
+	ui.DebugUI().SetClipboard(&clipboard)
+	// Collapse the long list of the colors to show the export header.
+	if err := ui.ClickOn("Colors"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.ClickOn("Export"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.ClickOn("Copy JSON to Clipboard"); err != nil {
+		t.Fatal(err)
+	}
+	got := debugui.DefaultTheme()
+	if err := json.Unmarshal([]byte(clipboard.text), got); err != nil {
+		t.Fatal(err)
+	}
+	if *got != *theme {
+		t.Errorf("exported theme: got: %v, want: %v", *got, *theme)
+	}
+}
+
+type testClipboard struct {
+	text string
+}
+
+func (c *testClipboard) ReadText() (string, error) {
+	return c.text, nil
+}
+
+func (c *testClipboard) WriteText(text string) error {
+	c.text = text
+	return nil
+}
+
+func TestFontFace(t *testing.T) {
+	f, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xface, err := opentype.NewFace(f, &opentype.FaceOptions{
+		Size: 32,
+		DPI:  72,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	face := text.NewGoXFace(xface)
+
+	var d debugui.DebugUI
+	if _, err := d.Update(func(ctx *debugui.Context) error {
+		ctx.SetFontFace(face)
+		ctx.Window("Window", image.Rect(0, 0, 300, 300), func(layout debugui.ContainerLayout) {
+			ctx.Button("Button")
+		})
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	lineHeight := int(math.Ceil(face.Metrics().HAscent + face.Metrics().HDescent + face.Metrics().HLineGap))
+	for w := range d.Widgets() {
+		if w.Label != "Button" {
+			continue
+		}
+		if got := w.Bounds.Dy(); got < lineHeight {
+			t.Errorf("button height: got: %d, want: >= %d", got, lineHeight)
+		}
+	}
+
+	var r commandRecorder
+	d.Render(&r)
+	for _, cmd := range r.commands {
+		if cmd, ok := cmd.(*debugui.TextCommand); ok && cmd.Face != face {
+			t.Errorf("face of %q: got: %v, want: %v", cmd.Text, cmd.Face, face)
+		}
+	}
+}
+
+func TestFractionalScale(t *testing.T) {
+	var count int
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.SetScale(1.5)
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Button("Button").On(func() {
+				count++
+			})
+		})
+		return nil
+	})
+
+	// Advance two ticks, as the layout is not settled at the first tick.
+	for range 2 {
+		if err := ui.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w, err := ui.Widget("Button")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The button is at (5, 29)-(195, 47) in the UI coordinate.
+	if got, want := w.Bounds, image.Rect(8, 44, 293, 71); got != want {
+		t.Errorf("button bounds: got: %v, want: %v", got, want)
+	}
+
+	// Click the bottom-right edge of the button.
+	if err := ui.MoveToPosition(292, 70); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Click(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+
+	img, err := ui.Render(320, 320)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The hovered button.
+	if got, want := img.RGBAAt(10, 46), (color.RGBA{95, 95, 95, 255}); got != want {
+		t.Errorf("img.At(10, 46): got: %v, want: %v", got, want)
+	}
+	// Outside the window.
+	if got, want := img.RGBAAt(303, 303), (color.RGBA{}); got != want {
+		t.Errorf("img.At(303, 303): got: %v, want: %v", got, want)
+	}
+	if got, want := img.RGBAAt(298, 298), (color.RGBA{45, 45, 45, 230}); got != want {
+		t.Errorf("img.At(298, 298): got: %v, want: %v", got, want)
+	}
+}
+
+func TestKeyboardNavigation(t *testing.T) {
+	var clicked int
+	var checked bool
+	value := 5
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Button("Button").On(func() {
+				clicked++
+			})
+			ctx.Checkbox(&checked, "Check")
+			ctx.Slider(&value, 0, 10, 1)
+		})
+		return nil
+	})
+	if err := ui.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if got := ui.InputCapturingState(); got&debugui.InputCapturingStateFocus != 0 {
+		t.Errorf("InputCapturingState: got: %v, want: not focus", got)
+	}
+
+	// Tab is left to the game while the UI is neither hovered nor focused.
+	if err := ui.MoveToPosition(300, 300); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.PressKey(ebiten.KeyTab); err != nil {
+		t.Fatal(err)
+	}
+	if got := ui.InputCapturingState(); got&debugui.InputCapturingStateFocus != 0 {
+		t.Errorf("InputCapturingState: got: %v, want: not focus", got)
+	}
+	if err := ui.MoveToPosition(100, 100); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tab moves the focus to the button.
+	if err := ui.PressKey(ebiten.KeyTab); err != nil {
+		t.Fatal(err)
+	}
+	if got := ui.InputCapturingState(); got&debugui.InputCapturingStateFocus == 0 {
+		t.Errorf("InputCapturingState: got: %v, want: focus", got)
+	}
+	if err := ui.PressKey(ebiten.KeySpace); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := clicked, 1; got != want {
+		t.Errorf("clicked: got: %d, want: %d", got, want)
+	}
+
+	// Tab moves the focus to the checkbox.
+	if err := ui.PressKey(ebiten.KeyTab); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.PressKey(ebiten.KeyEnter); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := checked, true; got != want {
+		t.Errorf("checked: got: %v, want: %v", got, want)
+	}
+
+	// Tab moves the focus to the slider.
+	if err := ui.PressKey(ebiten.KeyTab); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.PressKey(ebiten.KeyRight); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := value, 6; got != want {
+		t.Errorf("value: got: %d, want: %d", got, want)
+	}
+
+	// Tab wraps around to the button, and Shift+Tab goes back to the slider.
+	if err := ui.PressKey(ebiten.KeyTab); err != nil {
+		t.Fatal(err)
+	}
+	ui.Input().SetKeyPressed(ebiten.KeyShift, true)
+	if err := ui.PressKey(ebiten.KeyTab); err != nil {
+		t.Fatal(err)
+	}
+	ui.Input().SetKeyPressed(ebiten.KeyShift, false)
+	if err := ui.PressKey(ebiten.KeyLeft); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := value, 5; got != want {
+		t.Errorf("value: got: %d, want: %d", got, want)
+	}
+
+	// The focus ring is drawn around the slider.
+	w, err := ui.Widget("5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := ui.Render(200, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ring := w.Bounds.Inset(-2)
+	if got, want := color.RGBAModel.Convert(img.At(ring.Min.X, ring.Min.Y+1)), debugui.DefaultTheme().Colors[debugui.ColorFocusRing]; got != want {
+		t.Errorf("focus ring color: got: %v, want: %v", got, want)
+	}
+
+	// Clicking hides the focus ring.
+	if err := ui.ClickOn("Check"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.PressKey(ebiten.KeySpace); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := checked, false; got != want {
+		t.Errorf("checked: got: %v, want: %v", got, want)
+	}
+}
+
+func TestGamepadNavigation(t *testing.T) {
+	var clicked []string
+	value := 5
+	var selected int
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window 2", image.Rect(210, 0, 410, 200), func(layout debugui.ContainerLayout) {
+			ctx.Button("Other").On(func() {
+				clicked = append(clicked, "Other")
+			})
+		})
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.SetGridLayout([]int{-1, -1}, nil)
+			ctx.Button("Left").On(func() {
+				clicked = append(clicked, "Left")
+			})
+			ctx.Button("Right").On(func() {
+				clicked = append(clicked, "Right")
+			})
+			ctx.Slider(&value, 0, 10, 1)
+			ctx.Dropdown(&selected, []string{"One", "Two"})
+		})
+		return nil
+	})
+
+	windowOrder := func() []string {
+		var titles []string
+		for w := range ui.DebugUI().Widgets() {
+			if w.Label == "Window" || w.Label == "Window 2" {
+				titles = append(titles, w.Label)
+			}
+		}
+		return titles
+	}
+
+	// The gamepad is left to the game while the UI is neither hovered nor focused.
+	if err := ui.MoveToPosition(500, 500); err != nil {
+		t.Fatal(err)
+	}
+	order := windowOrder()
+	for _, b := range []ebiten.StandardGamepadButton{
+		ebiten.StandardGamepadButtonLeftBottom,
+		ebiten.StandardGamepadButtonFrontTopRight,
+	} {
+		if err := ui.PressGamepadButton(b); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := ui.InputCapturingState(), debugui.InputCapturingState(0); got != want {
+			t.Errorf("InputCapturingState: got: %v, want: %v", got, want)
+		}
+	}
+	if got, want := windowOrder(), order; !slices.Equal(got, want) {
+		t.Errorf("window order: got: %q, want: %q", got, want)
+	}
+	if err := ui.MoveToPosition(100, 100); err != nil {
+		t.Fatal(err)
+	}
+
+	// The D-pad moves the focus spatially, and A activates the focused widget.
+	for _, b := range []ebiten.StandardGamepadButton{
+		ebiten.StandardGamepadButtonLeftBottom,
+		ebiten.StandardGamepadButtonRightBottom,
+		ebiten.StandardGamepadButtonLeftRight,
+		ebiten.StandardGamepadButtonRightBottom,
+	} {
+		if err := ui.PressGamepadButton(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := clicked, []string{"Left", "Right"}; !slices.Equal(got, want) {
+		t.Errorf("clicked: got: %q, want: %q", got, want)
+	}
+
+	// The left stick adjusts the focused slider, which is at the lower left.
+	for _, b := range []ebiten.StandardGamepadButton{
+		ebiten.StandardGamepadButtonLeftBottom,
+		ebiten.StandardGamepadButtonLeftLeft,
+	} {
+		if err := ui.PressGamepadButton(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ui.Input().SetGamepadAxisValue(ebiten.StandardGamepadAxisLeftStickHorizontal, 1)
+	if err := ui.Update(); err != nil {
+		t.Fatal(err)
+	}
+	ui.Input().SetGamepadAxisValue(ebiten.StandardGamepadAxisLeftStickHorizontal, 0)
+	if err := ui.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := value, 6; got != want {
+		t.Errorf("value: got: %d, want: %d", got, want)
+	}
+
+	// A opens the dropdown, and the focus moves to its options.
+	for _, b := range []ebiten.StandardGamepadButton{
+		ebiten.StandardGamepadButtonLeftRight,
+		ebiten.StandardGamepadButtonRightBottom,
+		ebiten.StandardGamepadButtonLeftBottom,
+		ebiten.StandardGamepadButtonRightBottom,
+	} {
+		if err := ui.PressGamepadButton(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := selected, 1; got != want {
+		t.Errorf("selected: got: %d, want: %d", got, want)
+	}
+
+	// B closes the dropdown.
+	for range 10 {
+		if err := ui.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ui.PressGamepadButton(ebiten.StandardGamepadButtonRightBottom); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("One"); err != nil {
+		t.Errorf("the dropdown must be open: %v", err)
+	}
+	if err := ui.PressGamepadButton(ebiten.StandardGamepadButtonRightRight); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("One"); err == nil {
+		t.Errorf("the dropdown must be closed")
+	}
+
+	// The shoulder buttons cycle the windows.
+	if err := ui.PressGamepadButton(ebiten.StandardGamepadButtonFrontTopRight); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.PressGamepadButton(ebiten.StandardGamepadButtonRightBottom); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := clicked, []string{"Left", "Right", "Other"}; !slices.Equal(got, want) {
+		t.Errorf("clicked: got: %q, want: %q", got, want)
+	}
+}
+
+func TestEditHistory(t *testing.T) {
+	value := 50
+	var checked bool
+	var history []debugui.EditRecord
+	var applied int
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.SetEditHistoryEnabled(true)
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.SetNextKey("slider")
+			ctx.Slider(&value, 0, 100, 1)
+			ctx.Checkbox(&checked, "Check")
+			ctx.Button("Undo").On(func() {
+				ctx.Undo()
+			})
+		})
+		history, applied = ctx.History()
+		return nil
+	})
+	pressWithControl := func(key ebiten.Key) {
+		t.Helper()
+		ui.Input().SetKeyPressed(ebiten.KeyControlLeft, true)
+		if err := ui.PressKey(key); err != nil {
+			t.Fatal(err)
+		}
+		ui.Input().SetKeyPressed(ebiten.KeyControlLeft, false)
+	}
+
+	// Dragging a slider is recorded as one edit.
+	if err := ui.MoveTo("Window/slider"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Press(); err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		if err := ui.MoveBy(10, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ui.Release(); err != nil {
+		t.Fatal(err)
+	}
+	dragged := value
+	if dragged == 50 {
+		t.Fatalf("value: got: %d, want: not 50", dragged)
+	}
+	if err := ui.ClickOn("Check"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(history), 2; got != want {
+		t.Fatalf("len(history): got: %d, want: %d", got, want)
+	}
+	if got, want := history[0], (debugui.EditRecord{Path: "Window/slider", Before: 50, After: dragged}); got != want {
+		t.Errorf("history[0]: got: %v, want: %v", got, want)
+	}
+	if got, want := history[1], (debugui.EditRecord{Path: "Window/Check", Before: false, After: true}); got != want {
+		t.Errorf("history[1]: got: %v, want: %v", got, want)
+	}
+
+	pressWithControl(ebiten.KeyZ)
+	if got, want := checked, false; got != want {
+		t.Errorf("checked: got: %v, want: %v", got, want)
+	}
+	pressWithControl(ebiten.KeyZ)
+	if got, want := value, 50; got != want {
+		t.Errorf("value: got: %d, want: %d", got, want)
+	}
+	pressWithControl(ebiten.KeyY)
+	if got, want := value, dragged; got != want {
+		t.Errorf("value: got: %d, want: %d", got, want)
+	}
+	if got, want := applied, 1; got != want {
+		t.Errorf("applied: got: %d, want: %d", got, want)
+	}
+
+	// A new edit discards the edits that can be redone.
+	if err := ui.ClickOn("Check"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(history), 2; got != want {
+		t.Fatalf("len(history): got: %d, want: %d", got, want)
+	}
+	if got, want := applied, 2; got != want {
+		t.Errorf("applied: got: %d, want: %d", got, want)
+	}
+
+	if err := ui.ClickOn("Undo"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := checked, false; got != want {
+		t.Errorf("checked: got: %v, want: %v", got, want)
+	}
+}
+
+func TestPerfWindow(t *testing.T) {
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 100, 100), func(layout debugui.ContainerLayout) {
+			ctx.Button("Button")
+		})
+		ctx.PerfWindow()
+		return nil
+	})
+	for range 3 {
+		if err := ui.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The root containers are listed with their command counts.
+	for _, path := range []string{"Performance/Memory/Goroutines:", "Performance/Debug UI/Window", "Performance/Debug UI/Performance"} {
+		if _, err := ui.Widget(path); err != nil {
+			t.Error(err)
+		}
+	}
+}
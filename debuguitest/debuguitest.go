@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+// Package debuguitest provides utilities to test debug UIs without a real input device.
+//
+// A UI runs a debug UI with synthetic input. Each operation like Click or Type advances one or more ticks,
+// so the results can be checked right after the operation.
+package debuguitest
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/ebitengine/debugui"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// UI is a debug UI driven by synthetic input.
+type UI struct {
+	debugUI             debugui.DebugUI
+	input               Input
+	f                   func(ctx *debugui.Context) error
+	inputCapturingState debugui.InputCapturingState
+	updated             bool
+}
+
+// New creates a new UI.
+//
+// f is called at every tick, in the same way as the function passed to debugui.DebugUI.Update.
+//
+// The scale of the UI is 1 by default regardless of the device scale factor, so that the results don't depend on the environment.
+// f can change the scale by debugui.Context.SetScale.
+func New(f func(ctx *debugui.Context) error) *UI {
+	u := &UI{}
+	u.f = func(ctx *debugui.Context) error {
+		if !u.updated {
+			ctx.SetScale(1)
+		}
+		return f(ctx)
+	}
+	u.debugUI.SetInputSource(&u.input)
+	return u
+}
+
+// DebugUI returns the underlying debug UI.
+func (u *UI) DebugUI() *debugui.DebugUI {
+	return &u.debugUI
+}
+
+// Input returns the input state.
+//
+// The input state can be modified directly. The modification is reflected at the next Update.
+func (u *UI) Input() *Input {
+	return &u.input
+}
+
+// InputCapturingState returns the input capturing state returned by the last Update.
+func (u *UI) InputCapturingState() debugui.InputCapturingState {
+	return u.inputCapturingState
+}
+
+// Update advances one tick with the current input state.
+func (u *UI) Update() error {
+	s, err := u.debugUI.Update(u.f)
+	if err != nil {
+		return err
+	}
+	u.inputCapturingState = s
+	u.updated = true
+	// Runes are input only in one tick.
+	u.input.Runes = u.input.Runes[:0]
+	return nil
+}
+
+// Widget returns the widget with the given label or ID path that was laid out in the last tick.
+//
+// An ID path is like "Demo Window/Game Config/Hi-Res". See also debugui.WidgetInfo.
+// If multiple widgets match the label, Widget returns an error. Use an ID path to specify one of them.
+//
+// If no tick has been advanced yet, Widget advances one tick first.
+func (u *UI) Widget(label string) (debugui.WidgetInfo, error) {
+	if !u.updated {
+		if err := u.Update(); err != nil {
+			return debugui.WidgetInfo{}, err
+		}
+	}
+	var byLabel, byPath []debugui.WidgetInfo
+	for w := range u.debugUI.Widgets() {
+		if w.Bounds.Empty() {
+			continue
+		}
+		if w.Path == label {
+			byPath = append(byPath, w)
+		} else if w.Label == label {
+			byLabel = append(byLabel, w)
+		}
+	}
+	// An ID path is preferred to a label.
+	widgets := byPath
+	if len(widgets) == 0 {
+		widgets = byLabel
+	}
+	switch len(widgets) {
+	case 0:
+		return debugui.WidgetInfo{}, fmt.Errorf("debuguitest: widget %q not found", label)
+	case 1:
+		return widgets[0], nil
+	default:
+		paths := make([]string, len(widgets))
+		for i, w := range widgets {
+			paths[i] = w.Path
+		}
+		return debugui.WidgetInfo{}, fmt.Errorf("debuguitest: widget %q is ambiguous: %q", label, paths)
+	}
+}
+
+// Center returns the center of the widget with the given label or ID path in the screen coordinate.
+func (u *UI) Center(label string) (image.Point, error) {
+	w, err := u.Widget(label)
+	if err != nil {
+		return image.Point{}, err
+	}
+	return w.Bounds.Min.Add(w.Bounds.Max).Div(2), nil
+}
+
+// MoveTo moves the pointer to the center of the widget with the given label or ID path, and advances one tick.
+func (u *UI) MoveTo(label string) error {
+	center, err := u.Center(label)
+	if err != nil {
+		return err
+	}
+	return u.MoveToPosition(center.X, center.Y)
+}
+
+// MoveToPosition moves the pointer to the position in the screen coordinate, and advances one tick.
+func (u *UI) MoveToPosition(x, y int) error {
+	u.input.CursorX = x
+	u.input.CursorY = y
+	return u.Update()
+}
+
+// MoveBy moves the pointer by (dx, dy) in the screen coordinate, and advances one tick.
+func (u *UI) MoveBy(dx, dy int) error {
+	return u.MoveToPosition(u.input.CursorX+dx, u.input.CursorY+dy)
+}
+
+// Press presses the left mouse button, and advances one tick.
+func (u *UI) Press() error {
+	u.input.SetMouseButtonPressed(ebiten.MouseButtonLeft, true)
+	return u.Update()
+}
+
+// Release releases the left mouse button, and advances one tick.
+func (u *UI) Release() error {
+	u.input.SetMouseButtonPressed(ebiten.MouseButtonLeft, false)
+	return u.Update()
+}
+
+// Click presses and releases the left mouse button at the current position.
+func (u *UI) Click() error {
+	if err := u.Press(); err != nil {
+		return err
+	}
+	if err := u.Release(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ClickButton presses and releases the mouse button at the current position.
+func (u *UI) ClickButton(button ebiten.MouseButton) error {
+	u.input.SetMouseButtonPressed(button, true)
+	if err := u.Update(); err != nil {
+		return err
+	}
+	u.input.SetMouseButtonPressed(button, false)
+	if err := u.Update(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DoubleClick clicks the left mouse button twice at the current position.
+func (u *UI) DoubleClick() error {
+	if err := u.Click(); err != nil {
+		return err
+	}
+	if err := u.Click(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ClickOn moves the pointer to the widget with the given label or ID path, and clicks it.
+func (u *UI) ClickOn(label string) error {
+	if err := u.MoveTo(label); err != nil {
+		return err
+	}
+	if err := u.Click(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Drag presses the left mouse button at the current position, moves the pointer by (dx, dy), and releases the button.
+func (u *UI) Drag(dx, dy int) error {
+	if err := u.Press(); err != nil {
+		return err
+	}
+	if err := u.MoveBy(dx, dy); err != nil {
+		return err
+	}
+	if err := u.Release(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DragWindow drags the title bar of the window with the given title by (dx, dy).
+func (u *UI) DragWindow(title string, dx, dy int) error {
+	if err := u.MoveTo(title); err != nil {
+		return err
+	}
+	if err := u.Drag(dx, dy); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Type inputs the string str as text, and advances one tick.
+//
+// A text field must be focused in advance, e.g. by clicking it.
+func (u *UI) Type(str string) error {
+	u.input.Runes = append(u.input.Runes[:0], []rune(str)...)
+	return u.Update()
+}
+
+// PressKey presses and releases the key.
+func (u *UI) PressKey(key ebiten.Key) error {
+	u.input.SetKeyPressed(key, true)
+	if err := u.Update(); err != nil {
+		return err
+	}
+	u.input.SetKeyPressed(key, false)
+	if err := u.Update(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// PressGamepadButton presses and releases the gamepad's button.
+func (u *UI) PressGamepadButton(button ebiten.StandardGamepadButton) error {
+	u.input.SetGamepadButtonPressed(button, true)
+	if err := u.Update(); err != nil {
+		return err
+	}
+	u.input.SetGamepadButtonPressed(button, false)
+	if err := u.Update(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,1071 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debuguitest_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ebitengine/debugui"
+	"github.com/ebitengine/debugui/debuguitest"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestButton(t *testing.T) {
+	var count int
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Button("Button").On(func() {
+				count++
+			})
+		})
+		return nil
+	})
+	if err := ui.ClickOn("Button"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+	if got := ui.InputCapturingState(); got&debugui.InputCapturingStateHover == 0 {
+		t.Errorf("InputCapturingState: got: %v, want: hover", got)
+	}
+}
+
+func TestCheckbox(t *testing.T) {
+	var checked bool
+	var count int
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Checkbox(&checked, "Check").On(func() {
+				count++
+			})
+		})
+		return nil
+	})
+	for i := range 3 {
+		if err := ui.ClickOn("Check"); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := checked, i%2 == 0; got != want {
+			t.Errorf("checked: got: %v, want: %v", got, want)
+		}
+	}
+	if got, want := count, 3; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+}
+
+func TestWidgetPath(t *testing.T) {
+	var checked bool
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Header("Config", true, func() {
+				ctx.SetNextKey("check")
+				ctx.Checkbox(&checked, "Check")
+			})
+		})
+		return nil
+	})
+	if err := ui.ClickOn("Window/Config/check"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := checked, true; got != want {
+		t.Errorf("checked: got: %v, want: %v", got, want)
+	}
+}
+
+func TestDragWindow(t *testing.T) {
+	var bounds image.Rectangle
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(10, 20, 210, 220), func(layout debugui.ContainerLayout) {
+			bounds = layout.Bounds
+		})
+		return nil
+	})
+	if err := ui.DragWindow("Window", 30, 40); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := bounds, image.Rect(40, 60, 240, 260); got != want {
+		t.Errorf("bounds: got: %v, want: %v", got, want)
+	}
+}
+
+func TestSlider(t *testing.T) {
+	var value int
+	var count int
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Slider(&value, 0, 100, 1).On(func() {
+				count++
+			})
+		})
+		return nil
+	})
+	if err := ui.MoveTo("0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Drag(1000, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := value, 100; got != want {
+		t.Errorf("value: got: %d, want: %d", got, want)
+	}
+	if count == 0 {
+		t.Errorf("the slider's event handler was not called")
+	}
+}
+
+func TestTextField(t *testing.T) {
+	buf := "Hello"
+	var submitted []string
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.TextField(&buf).On(func() {
+				submitted = append(submitted, buf)
+			})
+		})
+		return nil
+	})
+
+	if err := ui.ClickOn("Hello"); err != nil {
+		t.Fatal(err)
+	}
+	if got := ui.InputCapturingState(); got&debugui.InputCapturingStateFocus == 0 {
+		t.Errorf("InputCapturingState: got: %v, want: focus", got)
+	}
+
+	if err := ui.Type("abc"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf, "Helloabc"; got != want {
+		t.Errorf("buf: got: %q, want: %q", got, want)
+	}
+	if err := ui.PressKey(ebiten.KeyBackspace); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Type("d"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.PressKey(ebiten.KeyEnter); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf, "Helloabd"; got != want {
+		t.Errorf("buf: got: %q, want: %q", got, want)
+	}
+	if got, want := len(submitted), 1; got != want {
+		t.Fatalf("len(submitted): got: %d, want: %d", got, want)
+	}
+	if got, want := submitted[0], "Helloabd"; got != want {
+		t.Errorf("submitted[0]: got: %q, want: %q", got, want)
+	}
+}
+
+type testClipboard struct {
+	text string
+}
+
+func (c *testClipboard) ReadText() (string, error) {
+	return c.text, nil
+}
+
+func (c *testClipboard) WriteText(text string) error {
+	c.text = text
+	return nil
+}
+
+func TestTextFieldEditing(t *testing.T) {
+	buf := "Hello World"
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.TextField(&buf)
+		})
+		return nil
+	})
+	clipboard := &testClipboard{}
+	ui.DebugUI().SetClipboard(clipboard)
+
+	pressKeys := func(keys ...ebiten.Key) {
+		t.Helper()
+		for _, key := range keys {
+			if err := ui.PressKey(key); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	pressKeysWithModifier := func(modifier ebiten.Key, keys ...ebiten.Key) {
+		t.Helper()
+		ui.Input().SetKeyPressed(modifier, true)
+		pressKeys(keys...)
+		ui.Input().SetKeyPressed(modifier, false)
+	}
+
+	// Clicking at the left edge places the caret at the start.
+	w, err := ui.Widget("Hello World")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.MoveToPosition(w.Bounds.Min.X+1, (w.Bounds.Min.Y+w.Bounds.Max.Y)/2); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Click(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Type(">"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf, ">Hello World"; got != want {
+		t.Errorf("buf: got: %q, want: %q", got, want)
+	}
+
+	pressKeys(ebiten.KeyRight, ebiten.KeyRight, ebiten.KeyLeft, ebiten.KeyDelete)
+	if got, want := buf, ">Hllo World"; got != want {
+		t.Errorf("buf: got: %q, want: %q", got, want)
+	}
+
+	pressKeys(ebiten.KeyHome)
+	pressKeysWithModifier(ebiten.KeyShiftLeft, ebiten.KeyRight, ebiten.KeyRight)
+	pressKeysWithModifier(ebiten.KeyControlLeft, ebiten.KeyC)
+	if got, want := clipboard.text, ">H"; got != want {
+		t.Errorf("clipboard: got: %q, want: %q", got, want)
+	}
+	pressKeys(ebiten.KeyEnd)
+	pressKeysWithModifier(ebiten.KeyControlLeft, ebiten.KeyV)
+	if got, want := buf, ">Hllo World>H"; got != want {
+		t.Errorf("buf: got: %q, want: %q", got, want)
+	}
+
+	// Typing replaces the selection.
+	pressKeysWithModifier(ebiten.KeyControlLeft, ebiten.KeyA)
+	if err := ui.Type("x"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf, "x"; got != want {
+		t.Errorf("buf: got: %q, want: %q", got, want)
+	}
+
+	pressKeysWithModifier(ebiten.KeyControlLeft, ebiten.KeyA, ebiten.KeyX)
+	if got, want := buf, ""; got != want {
+		t.Errorf("buf: got: %q, want: %q", got, want)
+	}
+	if got, want := clipboard.text, "x"; got != want {
+		t.Errorf("clipboard: got: %q, want: %q", got, want)
+	}
+
+	// Line breaks are not pasted into a text field.
+	clipboard.text = "a\nb"
+	pressKeysWithModifier(ebiten.KeyControlLeft, ebiten.KeyV)
+	if got, want := buf, "a b"; got != want {
+		t.Errorf("buf: got: %q, want: %q", got, want)
+	}
+}
+
+func TestTextArea(t *testing.T) {
+	buf := "first\nsecond"
+	var submitted []string
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.SetGridLayout(nil, []int{80})
+			ctx.SetNextKey("area")
+			ctx.TextArea(&buf).On(func() {
+				submitted = append(submitted, buf)
+			})
+		})
+		return nil
+	})
+
+	// Clicking the upper-left corner puts the caret at the head of the text.
+	w, err := ui.Widget("Window/area")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.MoveToPosition(w.Bounds.Min.X+1, w.Bounds.Min.Y+1); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Click(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Type("X"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf, "Xfirst\nsecond"; got != want {
+		t.Errorf("buf: got: %q, want: %q", got, want)
+	}
+
+	// Down and End move the caret to the tail of the second line, and Enter inserts a line break.
+	for _, key := range []ebiten.Key{ebiten.KeyDown, ebiten.KeyEnd, ebiten.KeyEnter} {
+		if err := ui.PressKey(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ui.Type("third"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf, "Xfirst\nsecond\nthird"; got != want {
+		t.Errorf("buf: got: %q, want: %q", got, want)
+	}
+
+	// Shift+Home selects the third line, and typing replaces the selection.
+	ui.Input().SetKeyPressed(ebiten.KeyShift, true)
+	if err := ui.PressKey(ebiten.KeyHome); err != nil {
+		t.Fatal(err)
+	}
+	ui.Input().SetKeyPressed(ebiten.KeyShift, false)
+	if err := ui.Type("3rd"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf, "Xfirst\nsecond\n3rd"; got != want {
+		t.Errorf("buf: got: %q, want: %q", got, want)
+	}
+
+	// Up and Backspace join the second line and the first line.
+	for _, key := range []ebiten.Key{ebiten.KeyUp, ebiten.KeyHome, ebiten.KeyBackspace} {
+		if err := ui.PressKey(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := buf, "Xfirstsecond\n3rd"; got != want {
+		t.Errorf("buf: got: %q, want: %q", got, want)
+	}
+
+	// Clicking outside confirms the value.
+	if err := ui.MoveToPosition(100, 180); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Click(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := submitted, []string{"Xfirstsecond\n3rd"}; !slices.Equal(got, want) {
+		t.Errorf("submitted: got: %q, want: %q", got, want)
+	}
+}
+
+func TestPlot(t *testing.T) {
+	// Values is a ring buffer, whose oldest value is 2 and newest value is 1.
+	// A negative start wraps around.
+	series := []debugui.PlotSeries{
+		{Label: "fps", Values: []float64{1, 2, 3, 4}, Start: -3},
+	}
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.SetGridLayout(nil, []int{80})
+			ctx.SetNextKey("plot")
+			ctx.Plot(series, nil)
+		})
+		return nil
+	})
+
+	w, err := ui.Widget("Window/plot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.Bounds.Dy(), 80; got != want {
+		t.Errorf("plot height: got: %d, want: %d", got, want)
+	}
+
+	for _, tc := range []struct {
+		x    int
+		want string
+	}{
+		{x: w.Bounds.Min.X + 8, want: "fps: 2"},
+		{x: w.Bounds.Max.X - 8, want: "fps: 1"},
+	} {
+		if err := ui.MoveToPosition(tc.x, (w.Bounds.Min.Y+w.Bounds.Max.Y)/2); err != nil {
+			t.Fatal(err)
+		}
+		// The value is shown in a tooltip, which is not clipped by the plot.
+		// A new tooltip is measured in the first frame, and shown in the next frame.
+		if err := ui.Update(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ui.Widget(tc.want); err != nil {
+			t.Errorf("tooltip at x=%d: %v", tc.x, err)
+		}
+	}
+}
+
+func TestDropdown(t *testing.T) {
+	var selected int
+	var count int
+	options := []string{"Apple", "Banana", "Cherry"}
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Dropdown(&selected, options).On(func() {
+				count++
+			})
+		})
+		return nil
+	})
+	if err := ui.ClickOn("Apple"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.ClickOn("Cherry"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := selected, 2; got != want {
+		t.Errorf("selected: got: %d, want: %d", got, want)
+	}
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+}
+
+func TestTooltip(t *testing.T) {
+	// The delay is kept even when the TPS is synchronized with the FPS.
+	ebiten.SetTPS(ebiten.SyncWithFPS)
+	defer ebiten.SetTPS(ebiten.DefaultTPS)
+
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Button("Save")
+			ctx.Tooltip("Save the settings")
+			ctx.Button("Load")
+		})
+		return nil
+	})
+
+	if err := ui.MoveTo("Save"); err != nil {
+		t.Fatal(err)
+	}
+	// MoveTo advances one tick, where the widget is hovered.
+	// The tooltip is measured at the end of the delay, and shown in the next tick.
+	for range ebiten.DefaultTPS / 2 {
+		if _, err := ui.Widget("Save the settings"); err == nil {
+			t.Fatal("the tooltip is shown before the delay")
+		}
+		if err := ui.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w, err := ui.Widget("Save the settings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	save, err := ui.Widget("Save")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Bounds.Overlaps(save.Bounds) {
+		t.Errorf("tooltip bounds: got: %v, want: not overlapping with %v", w.Bounds, save.Bounds)
+	}
+	// The tooltip doesn't capture the pointing device.
+	if got := ui.InputCapturingState(); got&debugui.InputCapturingStateHover == 0 {
+		t.Errorf("InputCapturingState: got: %v, want: hover", got)
+	}
+
+	if err := ui.MoveTo("Load"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Save the settings"); err == nil {
+		t.Error("the tooltip is shown after the pointer leaves the widget")
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	var count int
+	chosen := -1
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(200, 200, 400, 400), func(layout debugui.ContainerLayout) {
+			modalID, c := ctx.Confirm("Delete Save File?", "The save file cannot be restored.", "Delete", "Cancel")
+			if c >= 0 {
+				chosen = c
+			}
+			ctx.Button("Open").On(func() {
+				ctx.OpenModal(modalID)
+			})
+			ctx.Button("Other").On(func() {
+				count++
+			})
+		})
+		return nil
+	})
+
+	if _, err := ui.Widget("Delete"); err == nil {
+		t.Fatal("the modal window is shown before it is opened")
+	}
+	if err := ui.ClickOn("Open"); err != nil {
+		t.Fatal(err)
+	}
+	// The modal window is measured in the first tick, and shown in the next tick.
+	if err := ui.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Delete"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := ui.InputCapturingState(), debugui.InputCapturingStateHover|debugui.InputCapturingStateFocus; got != want {
+		t.Errorf("InputCapturingState: got: %v, want: %v", got, want)
+	}
+
+	// The windows behind the modal window don't get any input.
+	if err := ui.ClickOn("Other"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := count, 0; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+
+	if err := ui.ClickOn("Cancel"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := chosen, 1; got != want {
+		t.Errorf("chosen: got: %d, want: %d", got, want)
+	}
+	if _, err := ui.Widget("Delete"); err == nil {
+		t.Error("the modal window is shown after a button is chosen")
+	}
+	if err := ui.ClickOn("Other"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+}
+
+func TestTabBar(t *testing.T) {
+	var selectAudio bool
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		// The window is too narrow to show all the tabs.
+		ctx.Window("Window", image.Rect(0, 0, 120, 200), func(layout debugui.ContainerLayout) {
+			tabBarID := ctx.TabBar(func() {
+				ctx.Tab("General", func() {
+					ctx.Button("General Button")
+				})
+				ctx.Tab("Physics", func() {
+					ctx.Button("Physics Button")
+				})
+				ctx.Tab("Audio", func() {
+					ctx.Button("Audio Button")
+				})
+			})
+			if selectAudio {
+				ctx.SelectTab(tabBarID, 2)
+				selectAudio = false
+			}
+		})
+		return nil
+	})
+
+	if _, err := ui.Widget("Window/General/General Button"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Audio"); err == nil {
+		t.Error("the overflowing tab is shown")
+	}
+	if err := ui.ClickOn("Physics"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Window/Physics/Physics Button"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("General Button"); err == nil {
+		t.Error("the content of an unselected tab is rendered")
+	}
+
+	// Tab moves the focus to the overflowing tab, and scrolls the tab into view.
+	if _, err := ui.Widget("Audio"); err == nil {
+		t.Error("the overflowing tab is shown")
+	}
+	if err := ui.PressKey(ebiten.KeyTab); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Audio"); err != nil {
+		t.Error(err)
+	}
+	if err := ui.PressKey(ebiten.KeyEnter); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Window/Audio/Audio Button"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.ClickOn("Physics"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The selected tab is scrolled into view.
+	selectAudio = true
+	if err := ui.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Window/Audio/Audio Button"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Audio"); err != nil {
+		t.Error(err)
+	}
+	if _, err := ui.Widget("General"); err == nil {
+		t.Error("the tab scrolled out is shown")
+	}
+}
+
+func TestRadioButton(t *testing.T) {
+	var difficulty, mode, count int
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 300, 200), func(layout debugui.ContainerLayout) {
+			ctx.RadioGroup(&difficulty, []string{"Easy", "Normal", "Hard"}, true).On(func() {
+				count++
+			})
+			ctx.SetGridLayout([]int{-1}, nil)
+			ctx.RadioButton(&mode, 0, "Fixed")
+			ctx.RadioButton(&mode, 1, "Variable")
+		})
+		return nil
+	})
+
+	easy, err := ui.Widget("Easy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hard, err := ui.Widget("Hard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if easy.Bounds.Min.Y != hard.Bounds.Min.Y {
+		t.Errorf("the horizontal radio buttons are not in a row: %v, %v", easy.Bounds, hard.Bounds)
+	}
+
+	if err := ui.ClickOn("Hard"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := difficulty, 2; got != want {
+		t.Errorf("difficulty: got: %d, want: %d", got, want)
+	}
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+	// Clicking the selected radio button doesn't change the selection.
+	if err := ui.ClickOn("Hard"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+
+	if err := ui.ClickOn("Variable"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mode, 1; got != want {
+		t.Errorf("mode: got: %d, want: %d", got, want)
+	}
+	if got, want := difficulty, 2; got != want {
+		t.Errorf("difficulty: got: %d, want: %d", got, want)
+	}
+}
+
+func TestColorEdit(t *testing.T) {
+	clr := color.RGBA{R: 0xff, A: 0xff}
+	var count int
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 300, 300), func(layout debugui.ContainerLayout) {
+			ctx.ColorEdit(&clr).On(func() {
+				count++
+			})
+		})
+		return nil
+	})
+
+	if _, err := ui.Widget("Hex"); err == nil {
+		t.Fatal("the popup is shown before the swatch is clicked")
+	}
+	if err := ui.ClickOn("#FF0000"); err != nil {
+		t.Fatal(err)
+	}
+	// A popup is resized to fit its content in the next frames.
+	for range 2 {
+		if err := ui.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := ui.Widget("Hex"); err != nil {
+		t.Fatal(err)
+	}
+
+	// enterHex replaces the text of the hex code field labeled by label.
+	// The field has the same label as the swatch, and the field is in front of the swatch.
+	enterHex := func(label, text string) {
+		t.Helper()
+		var hex debugui.WidgetInfo
+		for w := range ui.DebugUI().Widgets() {
+			if w.Label == label {
+				hex = w
+			}
+		}
+		if err := ui.MoveToPosition(hex.Bounds.Min.X+1, hex.Bounds.Min.Y+1); err != nil {
+			t.Fatal(err)
+		}
+		if err := ui.Click(); err != nil {
+			t.Fatal(err)
+		}
+		ui.Input().SetKeyPressed(ebiten.KeyControlLeft, true)
+		if err := ui.PressKey(ebiten.KeyA); err != nil {
+			t.Fatal(err)
+		}
+		ui.Input().SetKeyPressed(ebiten.KeyControlLeft, false)
+		if err := ui.Type(text); err != nil {
+			t.Fatal(err)
+		}
+		if err := ui.PressKey(ebiten.KeyEnter); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	enterHex("#FF0000", "00FF0080")
+	// The color is premultiplied by the alpha.
+	if got, want := clr, (color.RGBA{G: 0x80, A: 0x80}); got != want {
+		t.Errorf("clr: got: %v, want: %v", got, want)
+	}
+	if got, want := count, 1; got != want {
+		t.Errorf("count: got: %d, want: %d", got, want)
+	}
+	// The hue is shown in the HSV fields.
+	if _, err := ui.Widget("120"); err != nil {
+		t.Error(err)
+	}
+
+	// A channel entered with a low alpha is kept as it is, though the premultiplied color loses the precision.
+	enterHex("#00FF0080", "FF00000A")
+	if err := ui.ClickOn("255"); err != nil {
+		t.Fatal(err)
+	}
+	ui.Input().SetKeyPressed(ebiten.KeyControlLeft, true)
+	if err := ui.PressKey(ebiten.KeyA); err != nil {
+		t.Fatal(err)
+	}
+	ui.Input().SetKeyPressed(ebiten.KeyControlLeft, false)
+	if err := ui.Type("200"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.PressKey(ebiten.KeyEnter); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := clr, color.RGBAModel.Convert(color.NRGBA{R: 200, A: 10}).(color.RGBA); got != want {
+		t.Errorf("clr: got: %v, want: %v", got, want)
+	}
+	if _, err := ui.Widget("200"); err != nil {
+		t.Error(err)
+	}
+	if _, err := ui.Widget("#C800000A"); err != nil {
+		t.Error(err)
+	}
+
+	// Clicking outside the popup closes it.
+	if err := ui.MoveToPosition(290, 290); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Click(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Hex"); err == nil {
+		t.Error("the popup is shown after clicking outside")
+	}
+	// The swatch shows the color converted back from the premultiplied color.
+	if _, err := ui.Widget("#B300000A"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMenu(t *testing.T) {
+	var saved, resetCount int
+	var grid bool
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.MenuBar(func() {
+				ctx.Menu("File", func() {
+					ctx.MenuItem("Save", "Ctrl+S").On(func() {
+						saved++
+					})
+					ctx.Menu("Recent", func() {
+						ctx.MenuItem("a.txt", "")
+					})
+				})
+				ctx.Menu("View", func() {
+					ctx.MenuCheckItem(&grid, "Grid", "")
+				})
+			})
+			ctx.Button("Target")
+			ctx.ContextMenu(func() {
+				ctx.MenuItem("Reset", "").On(func() {
+					resetCount++
+				})
+			})
+		})
+		return nil
+	})
+
+	if err := ui.ClickOn("Window/File"); err != nil {
+		t.Fatal(err)
+	}
+	w, err := ui.Widget("Window/File/Save")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.Label, "Save Ctrl+S"; got != want {
+		t.Errorf("label: got: %q, want: %q", got, want)
+	}
+
+	// Hovering a submenu item opens the submenu.
+	if err := ui.MoveTo("Window/File/Recent"); err != nil {
+		t.Fatal(err)
+	}
+	// The submenu is measured in the first tick, and shown in the next tick.
+	if err := ui.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Window/File/Recent/a.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Hovering another menu of the menu bar switches the open menu.
+	if err := ui.MoveTo("Window/View"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Window/File/Save"); err == nil {
+		t.Error("the menu is shown after another menu is opened")
+	}
+	if err := ui.ClickOn("Window/View/Grid"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := grid, true; got != want {
+		t.Errorf("grid: got: %v, want: %v", got, want)
+	}
+	if _, err := ui.Widget("Window/View/Grid"); err == nil {
+		t.Error("the menu is shown after an item is chosen")
+	}
+
+	// Right-clicking a widget opens its context menu.
+	if err := ui.MoveTo("Target"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.ClickButton(ebiten.MouseButtonRight); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.ClickOn("Reset"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := resetCount, 1; got != want {
+		t.Errorf("resetCount: got: %d, want: %d", got, want)
+	}
+	if got, want := saved, 0; got != want {
+		t.Errorf("saved: got: %d, want: %d", got, want)
+	}
+
+	// Clicking outside the menus closes them.
+	if err := ui.ClickOn("Window/File"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Window/File/Save"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.MoveToPosition(150, 180); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.Click(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ui.Widget("Window/File/Save"); err == nil {
+		t.Error("the menu is shown after clicking outside")
+	}
+}
+
+func TestClicks(t *testing.T) {
+	var clicked, secondaryClicked, middleClicked, doubleClicked int
+	var interval time.Duration
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.SetDoubleClickInterval(interval)
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+			ctx.Button("Button").On(func() {
+				clicked++
+			})
+			ctx.SecondaryClicked().On(func() {
+				secondaryClicked++
+			})
+			ctx.MiddleClicked().On(func() {
+				middleClicked++
+			})
+			ctx.DoubleClicked().On(func() {
+				doubleClicked++
+			})
+			ctx.Button("Other")
+		})
+		return nil
+	})
+
+	if err := ui.MoveTo("Button"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.ClickButton(ebiten.MouseButtonRight); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.ClickButton(ebiten.MouseButtonMiddle); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := secondaryClicked, 1; got != want {
+		t.Errorf("secondaryClicked: got: %d, want: %d", got, want)
+	}
+	if got, want := middleClicked, 1; got != want {
+		t.Errorf("middleClicked: got: %d, want: %d", got, want)
+	}
+	if got, want := clicked, 0; got != want {
+		t.Errorf("clicked: got: %d, want: %d", got, want)
+	}
+
+	if err := ui.DoubleClick(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := clicked, 2; got != want {
+		t.Errorf("clicked: got: %d, want: %d", got, want)
+	}
+	if got, want := doubleClicked, 1; got != want {
+		t.Errorf("doubleClicked: got: %d, want: %d", got, want)
+	}
+
+	// A third click is not a double click.
+	if err := ui.Click(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := doubleClicked, 1; got != want {
+		t.Errorf("doubleClicked: got: %d, want: %d", got, want)
+	}
+
+	// Clicks slower than the interval are not a double click.
+	interval = time.Second / 60
+	for range 10 {
+		if err := ui.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ui.DoubleClick(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := doubleClicked, 1; got != want {
+		t.Errorf("doubleClicked: got: %d, want: %d", got, want)
+	}
+
+	// Secondary clicks on another widget don't fire the events.
+	if err := ui.MoveTo("Other"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.ClickButton(ebiten.MouseButtonRight); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := secondaryClicked, 1; got != want {
+		t.Errorf("secondaryClicked: got: %d, want: %d", got, want)
+	}
+}
+
+func TestWidgetNotFound(t *testing.T) {
+	ui := debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(0, 0, 200, 200), func(layout debugui.ContainerLayout) {
+		})
+		return nil
+	})
+	if err := ui.ClickOn("Missing"); err == nil {
+		t.Errorf("ClickOn must return an error for a missing widget")
+	}
+}
+
+func newGalleryUI() *debuguitest.UI {
+	var checked bool
+	var value int
+	var valueF float64
+	var selected int
+	buf := "Text"
+	return debuguitest.New(func(ctx *debugui.Context) error {
+		ctx.Window("Window", image.Rect(10, 10, 250, 290), func(layout debugui.ContainerLayout) {
+			ctx.Button("Button")
+			ctx.Checkbox(&checked, "Checkbox")
+			ctx.Header("Header", true, func() {
+				ctx.SetGridLayout([]int{-1, -2}, nil)
+				ctx.Text("Slider:")
+				ctx.Slider(&value, 0, 100, 1)
+				ctx.Text("Number:")
+				ctx.NumberFieldF(&valueF, 0.1, 2)
+				ctx.Text("Dropdown:")
+				ctx.Dropdown(&selected, []string{"Apple", "Banana"})
+				ctx.Text("Text:")
+				ctx.TextField(&buf)
+			})
+			ctx.TreeNode("Tree", func() {
+				ctx.Text("Leaf")
+			})
+			ctx.Text("The quick brown fox jumps over the lazy dog.")
+		})
+		return nil
+	})
+}
+
+func TestMatchGolden(t *testing.T) {
+	ui := newGalleryUI()
+	if err := ui.ClickOn("Checkbox"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.ClickOn("Tree"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ui.MatchGolden(filepath.Join("testdata", "gallery.png"), 260, 300); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMatchGoldenMismatch(t *testing.T) {
+	ui := newGalleryUI()
+	path := filepath.Join(t.TempDir(), "golden.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, image.NewRGBA(image.Rect(0, 0, 260, 300))); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	err = ui.MatchGolden(path, 260, 300)
+	var mismatchErr *debuguitest.GoldenMismatchError
+	if !errors.As(err, &mismatchErr) {
+		t.Fatalf("MatchGolden must return a GoldenMismatchError but: %v", err)
+	}
+	if mismatchErr.Mismatches == 0 {
+		t.Errorf("Mismatches must not be 0")
+	}
+	if _, err := os.Stat(mismatchErr.DiffPath); err != nil {
+		t.Errorf("the diff image must be written: %v", err)
+	}
+}
+
+func TestCompareImages(t *testing.T) {
+	img0 := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	img1 := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	img1.Set(1, 2, color.White)
+	img1.Set(3, 3, color.White)
+
+	n, diff := debuguitest.CompareImages(img0, img0)
+	if got, want := n, 0; got != want {
+		t.Errorf("mismatches: got: %d, want: %d", got, want)
+	}
+	n, diff = debuguitest.CompareImages(img0, img1)
+	if got, want := n, 2; got != want {
+		t.Errorf("mismatches: got: %d, want: %d", got, want)
+	}
+	if got, want := diff.RGBAAt(1, 2), (color.RGBA{0xff, 0, 0, 0xff}); got != want {
+		t.Errorf("diff.At(1, 2): got: %v, want: %v", got, want)
+	}
+	if got, want := diff.RGBAAt(0, 0), (color.RGBA{0, 0, 0, 0xff}); got != want {
+		t.Errorf("diff.At(0, 0): got: %v, want: %v", got, want)
+	}
+}
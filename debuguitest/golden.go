@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debuguitest
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/ebitengine/debugui"
+)
+
+// UpdateGoldenEnv is the name of the environment variable to update golden files.
+//
+// If the environment variable is not empty, MatchGolden writes the rendering result to the golden file instead of comparing.
+const UpdateGoldenEnv = "DEBUGUITEST_UPDATE_GOLDEN"
+
+// GoldenMismatchError is an error returned by MatchGolden when the rendering result doesn't match the golden file.
+type GoldenMismatchError struct {
+	// Path is the path of the golden file.
+	Path string
+
+	// Mismatches is the number of mismatched pixels.
+	Mismatches int
+
+	// DiffPath is the path of the diff image.
+	DiffPath string
+}
+
+// Error implements error.
+func (g *GoldenMismatchError) Error() string {
+	return fmt.Sprintf("debuguitest: %d pixels mismatched with %s; see %s", g.Mismatches, g.Path, g.DiffPath)
+}
+
+// Render renders the result of the last tick onto a new image with the given size.
+//
+// Render uses debugui.ImageRenderer, so a GPU is not required.
+// If no tick has been advanced yet, Render advances one tick first.
+func (u *UI) Render(width, height int) (*image.RGBA, error) {
+	if !u.updated {
+		if err := u.Update(); err != nil {
+			return nil, err
+		}
+	}
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	u.debugUI.Render(&debugui.ImageRenderer{Target: img})
+	return img, nil
+}
+
+// MatchGolden renders the result of the last tick onto an image with the given size,
+// and compares it with the PNG file at path.
+//
+// If the images don't match, MatchGolden writes an image highlighting the mismatched pixels
+// next to the golden file with the suffix ".diff.png", and returns a *GoldenMismatchError.
+//
+// If the environment variable UpdateGoldenEnv is not empty, MatchGolden writes the rendering result to path instead.
+func (u *UI) MatchGolden(path string, width, height int) error {
+	got, err := u.Render(width, height)
+	if err != nil {
+		return err
+	}
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		return writePNG(path, got)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("debuguitest: golden file %s doesn't exist; set %s=1 to create it: %w", path, UpdateGoldenEnv, err)
+		}
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	want, err := png.Decode(f)
+	if err != nil {
+		return fmt.Errorf("debuguitest: decoding %s failed: %w", path, err)
+	}
+
+	if got.Bounds().Size() != want.Bounds().Size() {
+		return fmt.Errorf("debuguitest: size mismatched with %s: got: %v, want: %v", path, got.Bounds().Size(), want.Bounds().Size())
+	}
+	mismatches, diff := CompareImages(got, want)
+	if mismatches == 0 {
+		return nil
+	}
+	diffPath := strings.TrimSuffix(path, ".png") + ".diff.png"
+	if err := writePNG(diffPath, diff); err != nil {
+		return err
+	}
+	return &GoldenMismatchError{
+		Path:       path,
+		Mismatches: mismatches,
+		DiffPath:   diffPath,
+	}
+}
+
+// CompareImages compares the images got and want pixel by pixel.
+//
+// CompareImages returns the number of mismatched pixels and an image highlighting them.
+// In the diff image, mismatched pixels are red, and the other pixels are a faded version of want.
+// The images are compared in the area of want's bounds.
+func CompareImages(got, want image.Image) (mismatches int, diff *image.RGBA) {
+	b := want.Bounds()
+	diff = image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	gb := got.Bounds()
+	for j := 0; j < b.Dy(); j++ {
+		for i := 0; i < b.Dx(); i++ {
+			// Compare the colors in the non-premultiplied 8-bit model, as PNG files store colors in this model.
+			wc := color.NRGBAModel.Convert(want.At(b.Min.X+i, b.Min.Y+j)).(color.NRGBA)
+			gc := color.NRGBAModel.Convert(got.At(gb.Min.X+i, gb.Min.Y+j)).(color.NRGBA)
+			if gc != wc {
+				mismatches++
+				diff.SetRGBA(i, j, color.RGBA{0xff, 0, 0, 0xff})
+				continue
+			}
+			// Fade the matched pixel so that the mismatched pixels stand out.
+			y := color.GrayModel.Convert(wc).(color.Gray).Y
+			diff.SetRGBA(i, j, color.RGBA{y / 4, y / 4, y / 4, 0xff})
+		}
+	}
+	return mismatches, diff
+}
+
+func writePNG(path string, img image.Image) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err2 := f.Close(); err2 != nil && err == nil {
+			err = err2
+		}
+	}()
+	if err := png.Encode(f, img); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debuguitest
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Input is a debugui.InputSource whose state is set directly.
+//
+// Input also implements debugui.GamepadInputSource, and represents one gamepad with the standard layout.
+//
+// The zero value for Input is ready to use, and represents no input.
+type Input struct {
+	// CursorX and CursorY are the position of the mouse cursor in the screen coordinate.
+	CursorX int
+	CursorY int
+
+	// WheelX and WheelY are the offsets of the mouse wheel.
+	WheelX float64
+	WheelY float64
+
+	// Runes are the runes input in the current tick.
+	Runes []rune
+
+	mouseButtons   []ebiten.MouseButton
+	keys           []ebiten.Key
+	gamepadButtons []ebiten.StandardGamepadButton
+	gamepadAxes    [ebiten.StandardGamepadAxisMax + 1]float64
+}
+
+// SetMouseButtonPressed sets whether the mouse button is pressed.
+func (i *Input) SetMouseButtonPressed(button ebiten.MouseButton, pressed bool) {
+	i.mouseButtons = slices.DeleteFunc(i.mouseButtons, func(b ebiten.MouseButton) bool {
+		return b == button
+	})
+	if pressed {
+		i.mouseButtons = append(i.mouseButtons, button)
+	}
+}
+
+// SetKeyPressed sets whether the key is pressed.
+func (i *Input) SetKeyPressed(key ebiten.Key, pressed bool) {
+	i.keys = slices.DeleteFunc(i.keys, func(k ebiten.Key) bool {
+		return k == key
+	})
+	if pressed {
+		i.keys = append(i.keys, key)
+	}
+}
+
+// SetGamepadButtonPressed sets whether the gamepad's button is pressed.
+func (i *Input) SetGamepadButtonPressed(button ebiten.StandardGamepadButton, pressed bool) {
+	i.gamepadButtons = slices.DeleteFunc(i.gamepadButtons, func(b ebiten.StandardGamepadButton) bool {
+		return b == button
+	})
+	if pressed {
+		i.gamepadButtons = append(i.gamepadButtons, button)
+	}
+}
+
+// SetGamepadAxisValue sets the value of the gamepad's axis in the range [-1, 1].
+func (i *Input) SetGamepadAxisValue(axis ebiten.StandardGamepadAxis, value float64) {
+	i.gamepadAxes[axis] = value
+}
+
+// CursorPosition implements debugui.InputSource.
+func (i *Input) CursorPosition() (x, y int) {
+	return i.CursorX, i.CursorY
+}
+
+// IsMouseButtonPressed implements debugui.InputSource.
+func (i *Input) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return slices.Contains(i.mouseButtons, button)
+}
+
+// AppendTouchIDs implements debugui.InputSource.
+func (i *Input) AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID {
+	return touches
+}
+
+// TouchPosition implements debugui.InputSource.
+func (i *Input) TouchPosition(id ebiten.TouchID) (x, y int) {
+	return 0, 0
+}
+
+// Wheel implements debugui.InputSource.
+func (i *Input) Wheel() (xoff, yoff float64) {
+	return i.WheelX, i.WheelY
+}
+
+// IsKeyPressed implements debugui.InputSource.
+func (i *Input) IsKeyPressed(key ebiten.Key) bool {
+	if slices.Contains(i.keys, key) {
+		return true
+	}
+	// Virtual keys like KeyShift are pressed when either of their physical keys is pressed.
+	switch key {
+	case ebiten.KeyAlt:
+		return i.IsKeyPressed(ebiten.KeyAltLeft) || i.IsKeyPressed(ebiten.KeyAltRight)
+	case ebiten.KeyControl:
+		return i.IsKeyPressed(ebiten.KeyControlLeft) || i.IsKeyPressed(ebiten.KeyControlRight)
+	case ebiten.KeyShift:
+		return i.IsKeyPressed(ebiten.KeyShiftLeft) || i.IsKeyPressed(ebiten.KeyShiftRight)
+	case ebiten.KeyMeta:
+		return i.IsKeyPressed(ebiten.KeyMetaLeft) || i.IsKeyPressed(ebiten.KeyMetaRight)
+	}
+	return false
+}
+
+// AppendInputChars implements debugui.InputSource.
+func (i *Input) AppendInputChars(runes []rune) []rune {
+	return append(runes, i.Runes...)
+}
+
+// AppendGamepadIDs implements debugui.GamepadInputSource.
+func (i *Input) AppendGamepadIDs(gamepadIDs []ebiten.GamepadID) []ebiten.GamepadID {
+	return append(gamepadIDs, 0)
+}
+
+// IsStandardGamepadButtonPressed implements debugui.GamepadInputSource.
+func (i *Input) IsStandardGamepadButtonPressed(id ebiten.GamepadID, button ebiten.StandardGamepadButton) bool {
+	return id == 0 && slices.Contains(i.gamepadButtons, button)
+}
+
+// StandardGamepadAxisValue implements debugui.GamepadInputSource.
+func (i *Input) StandardGamepadAxisValue(id ebiten.GamepadID, axis ebiten.StandardGamepadAxis) float64 {
+	if id != 0 {
+		return 0
+	}
+	return i.gamepadAxes[axis]
+}
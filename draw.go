@@ -9,12 +9,12 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"math"
 	"sync"
 
 	"github.com/hajimehoshi/bitmapfont/v4"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 const (
@@ -26,61 +26,117 @@ var (
 	unclippedRect = image.Rect(0, 0, 0x1000000, 0x1000000)
 )
 
-var fontFace = text.NewGoXFace(bitmapfont.Face)
+var defaultFontFace = text.NewGoXFace(bitmapfont.Face)
 
 // DrawText draws the text on the destination image with the given options,
-// in the same way as debugui's widget text drawing.
+// in the same way as debugui's widget text drawing with the default font face.
 //
 // Note that you have to specify the scale at the options when the context scale is not 1.
+// To draw a text with the font face set by [Context.SetFontFace], use text.Draw with [Context.FontFace].
 func DrawText(dst *ebiten.Image, str string, options *text.DrawOptions) {
-	text.Draw(dst, str, fontFace, options)
+	text.Draw(dst, str, defaultFontFace, options)
 }
 
-func textWidth(str string) int {
-	return int(text.Advance(str, fontFace))
+// SetFontFace sets the font face to draw texts.
+//
+// The layout metrics like the default height of widgets and the indent of tree nodes are enlarged to fit the font face if needed.
+// If face is nil, the default bitmap font face is used.
+// See [Context.SetScale] for how the face is rendered at a non-integer scale.
+func (c *Context) SetFontFace(face text.Face) {
+	c.face = face
+}
+
+// FontFace returns the font face to draw texts.
+func (c *Context) FontFace() text.Face {
+	if c.face == nil {
+		return defaultFontFace
+	}
+	return c.face
+}
+
+func (c *Context) textWidth(str string) int {
+	return textWidth(str, c.FontFace())
+}
+
+func (c *Context) lineHeight() int {
+	return lineHeight(c.FontFace())
+}
+
+func textWidth(str string, face text.Face) int {
+	return int(math.Ceil(text.Advance(str, face)))
 }
 
-func lineHeight() int {
-	return int(fontFace.Metrics().HAscent + fontFace.Metrics().HDescent + fontFace.Metrics().HLineGap)
+func lineHeight(face text.Face) int {
+	m := face.Metrics()
+	return int(math.Ceil(m.HAscent + m.HDescent + m.HLineGap))
 }
 
-type icon int
+// Icon is an icon drawn by the debug UI.
+type Icon int
 
 const (
-	iconCheck icon = iota + 1
-	iconCollapsed
-	iconExpanded
-	iconDown
-	iconUp
+	// IconCheck is a check mark used by a checkbox.
+	IconCheck Icon = iota + 1
+
+	// IconCollapsed is a right-pointing triangle used by a collapsed header or window.
+	IconCollapsed
+
+	// IconExpanded is a down-pointing triangle used by an expanded header or window.
+	IconExpanded
+
+	// IconDown is a down arrow used by a number field or a dropdown.
+	IconDown
+
+	// IconUp is an up arrow used by a number field or a dropdown.
+	IconUp
+
+	// IconLeft is a left arrow used by a tab bar.
+	IconLeft
+
+	// IconRight is a right arrow used by a tab bar.
+	IconRight
+
+	// IconRadio is a dot used by a radio button.
+	IconRadio
 )
 
 var (
 	//go:embed icon/*.png
 	iconFS  embed.FS
-	iconMap = map[icon]*ebiten.Image{}
+	iconMap = map[Icon]image.Image{}
 	iconM   sync.Mutex
 )
 
-func iconImage(icon icon) *ebiten.Image {
+// Image returns the image of the icon.
+//
+// The icon image is white, and is expected to be scaled with a color.
+// Image returns nil if the icon is invalid.
+func (i Icon) Image() image.Image {
 	iconM.Lock()
 	defer iconM.Unlock()
 
-	if img, ok := iconMap[icon]; ok {
+	if img, ok := iconMap[i]; ok {
 		return img
 	}
 
 	var name string
-	switch icon {
-	case iconCheck:
+	switch i {
+	case IconCheck:
 		name = "check.png"
-	case iconCollapsed:
+	case IconCollapsed:
 		name = "collapsed.png"
-	case iconExpanded:
+	case IconExpanded:
 		name = "expanded.png"
-	case iconDown:
+	case IconDown:
 		name = "down.png"
-	case iconUp:
+	case IconUp:
 		name = "up.png"
+	case IconLeft:
+		name = "left.png"
+	case IconRight:
+		name = "right.png"
+	case IconRadio:
+		name = "radio.png"
 	default:
 		return nil
 	}
@@ -92,66 +148,17 @@ func iconImage(icon icon) *ebiten.Image {
 	if err != nil {
 		panic(fmt.Sprintf("debugui: %v", err))
 	}
-	iconMap[icon] = ebiten.NewImageFromImage(img)
-	return iconMap[icon]
-}
-
-func (c *Context) draw(screen *ebiten.Image) {
-	if c.err != nil {
-		return
-	}
-
-	target := screen
-	scale := c.Scale()
-	for cmd := range c.commands() {
-		switch cmd.typ {
-		case commandRect:
-			vector.DrawFilledRect(
-				target,
-				float32(cmd.rect.rect.Min.X*scale),
-				float32(cmd.rect.rect.Min.Y*scale),
-				float32(cmd.rect.rect.Dx()*scale),
-				float32(cmd.rect.rect.Dy()*scale),
-				cmd.rect.color,
-				false,
-			)
-		case commandText:
-			op := &text.DrawOptions{}
-			op.GeoM.Translate(float64(cmd.text.pos.X), float64(cmd.text.pos.Y))
-			op.GeoM.Scale(float64(scale), float64(scale))
-			op.ColorScale.ScaleWithColor(cmd.text.color)
-			text.Draw(target, cmd.text.str, fontFace, op)
-		case commandIcon:
-			img := iconImage(cmd.icon.icon)
-			if img == nil {
-				continue
-			}
-			op := &ebiten.DrawImageOptions{}
-			x := cmd.icon.rect.Min.X + (cmd.icon.rect.Dx()-img.Bounds().Dx())/2
-			y := cmd.icon.rect.Min.Y + (cmd.icon.rect.Dy()-img.Bounds().Dy())/2
-			op.GeoM.Translate(float64(x), float64(y))
-			op.GeoM.Scale(float64(scale), float64(scale))
-			op.ColorScale.ScaleWithColor(cmd.icon.color)
-			target.DrawImage(img, op)
-		case commandDraw:
-			cmd.draw.f(target)
-		case commandClip:
-			r := cmd.clip.rect
-			r.Min.X *= scale
-			r.Min.Y *= scale
-			r.Max.X *= scale
-			r.Max.Y *= scale
-			target = screen.SubImage(r).(*ebiten.Image)
-		}
-	}
+	iconMap[i] = img
+	return iconMap[i]
 }
 
 func (c *Context) drawRect(rect image.Rectangle, color color.Color) {
 	rect2 := rect.Intersect(c.clipRect())
 	if rect2.Dx() > 0 && rect2.Dy() > 0 {
-		cmd := c.appendCommand(commandRect)
-		cmd.rect.rect = rect2
-		cmd.rect.color = color
+		c.appendCommand(&RectCommand{
+			Rect:  rect2,
+			Color: color,
+		})
 	}
 }
 
@@ -162,8 +169,28 @@ func (c *Context) drawBox(rect image.Rectangle, color color.Color) {
 	c.drawRect(image.Rect(rect.Max.X-1, rect.Min.Y, rect.Max.X, rect.Max.Y), color)
 }
 
+func (c *Context) drawLine(from, to image.Point, color color.Color) {
+	rect := image.Rectangle{Min: from, Max: to}.Canon()
+	rect.Max = rect.Max.Add(image.Pt(1, 1))
+	clipped := c.checkClip(rect)
+	if clipped == clipAll {
+		return
+	}
+	if clipped == clipPart {
+		c.setClip(c.clipRect())
+	}
+	c.appendCommand(&LineCommand{
+		From:  from,
+		To:    to,
+		Color: color,
+	})
+	if clipped != 0 {
+		c.setClip(unclippedRect)
+	}
+}
+
 func (c *Context) drawText(str string, pos image.Point, color color.Color) {
-	rect := image.Rect(pos.X, pos.Y, pos.X+textWidth(str), pos.Y+lineHeight())
+	rect := image.Rect(pos.X, pos.Y, pos.X+c.textWidth(str), pos.Y+c.lineHeight())
 	clipped := c.checkClip(rect)
 	if clipped == clipAll {
 		return
@@ -171,18 +198,23 @@ func (c *Context) drawText(str string, pos image.Point, color color.Color) {
 	if clipped == clipPart {
 		c.setClip(c.clipRect())
 	}
+	if c.drawingWidgetInfo != nil {
+		c.drawingWidgetInfo.labels = append(c.drawingWidgetInfo.labels, str)
+	}
 	// add command
-	cmd := c.appendCommand(commandText)
-	cmd.text.str = str
-	cmd.text.pos = pos
-	cmd.text.color = color
+	c.appendCommand(&TextCommand{
+		Position: pos,
+		Color:    color,
+		Text:     str,
+		Face:     c.FontFace(),
+	})
 	// reset clipping if it was set
 	if clipped != 0 {
 		c.setClip(unclippedRect)
 	}
 }
 
-func (c *Context) drawIcon(icon icon, rect image.Rectangle, color color.Color) {
+func (c *Context) drawIcon(icon Icon, rect image.Rectangle, color color.Color) {
 	// do clip command if the rect isn't fully contained within the cliprect
 	clipped := c.checkClip(rect)
 	if clipped == clipAll {
@@ -192,10 +224,11 @@ func (c *Context) drawIcon(icon icon, rect image.Rectangle, color color.Color) {
 		c.setClip(c.clipRect())
 	}
 	// do icon command
-	cmd := c.appendCommand(commandIcon)
-	cmd.icon.icon = icon
-	cmd.icon.rect = rect
-	cmd.icon.color = color
+	c.appendCommand(&IconCommand{
+		Rect:  rect,
+		Icon:  icon,
+		Color: color,
+	})
 	// reset clipping if it was set
 	if clipped != 0 {
 		c.setClip(unclippedRect)
@@ -208,54 +241,56 @@ func (c *Context) DrawOnlyWidget(f func(screen *ebiten.Image)) {
 		_, _ = c.widget(widgetID{}, 0, nil, nil, func(bounds image.Rectangle) {
 			c.setClip(c.clipRect())
 			defer c.setClip(unclippedRect)
-			cmd := c.appendCommand(commandDraw)
-			cmd.draw.f = f
+			c.appendCommand(&DrawCommand{
+				Draw: f,
+			})
 		})
 		return nil, nil
 	})
 }
 
-func (c *Context) drawFrame(rect image.Rectangle, colorid int) {
-	c.drawRect(rect, c.style().colors[colorid])
-	if colorid == colorScrollBase || colorid == colorTitleBG {
+func (c *Context) drawFrame(rect image.Rectangle, colorSlot ColorSlot) {
+	c.drawRect(rect, c.style().Colors[colorSlot])
+	if colorSlot == ColorScrollBase || colorSlot == ColorTitleBG {
 		return
 	}
-	if c.style().colors[colorBorder].A != 0 {
-		c.drawBox(rect.Inset(-1), c.style().colors[colorBorder])
+	if c.style().Colors[ColorBorder].A != 0 {
+		c.drawBox(rect.Inset(-1), c.style().Colors[ColorBorder])
 	}
 }
 
-func (c *Context) drawWidgetFrame(id widgetID, rect image.Rectangle, colorid int, opt option) {
+func (c *Context) drawWidgetFrame(id widgetID, rect image.Rectangle, colorSlot ColorSlot, opt option) {
 	if (opt & optionNoFrame) != 0 {
 		return
 	}
 	if c.focus == id {
-		colorid += 2
+		colorSlot += 2
 	} else if c.hover == id {
-		colorid++
+		colorSlot++
 	}
-	c.drawFrame(rect, colorid)
+	c.drawFrame(rect, colorSlot)
 }
 
-func (c *Context) drawWidgetText(str string, rect image.Rectangle, colorid int, opt option) {
+func (c *Context) drawWidgetText(str string, rect image.Rectangle, colorSlot ColorSlot, opt option) {
 	var pos image.Point
-	tw := textWidth(str)
+	tw := c.textWidth(str)
 	c.pushClipRect(rect)
-	pos.Y = rect.Min.Y + (rect.Dy()-lineHeight())/2
+	pos.Y = rect.Min.Y + (rect.Dy()-c.lineHeight())/2
 	if (opt & optionAlignCenter) != 0 {
 		pos.X = rect.Min.X + (rect.Dx()-tw)/2
 	} else if (opt & optionAlignRight) != 0 {
-		pos.X = rect.Min.X + rect.Dx() - tw - c.style().padding
+		pos.X = rect.Min.X + rect.Dx() - tw - c.style().Padding
 	} else {
-		pos.X = rect.Min.X + c.style().padding
+		pos.X = rect.Min.X + c.style().Padding
 	}
-	c.drawText(str, pos, c.style().colors[colorid])
+	c.drawText(str, pos, c.style().Colors[colorSlot])
 	c.popClipRect()
 }
 
 func (c *Context) setClip(rect image.Rectangle) {
-	cmd := c.appendCommand(commandClip)
-	cmd.clip.rect = rect
+	c.appendCommand(&ClipCommand{
+		Rect: rect,
+	})
 }
 
 func (c *Context) pushClipRect(rect image.Rectangle) {
@@ -284,21 +319,55 @@ func (c *Context) checkClip(bounds image.Rectangle) int {
 
 // SetScale sets the scale of the UI.
 //
-// The scale affects the rendering result of the UI.
+// The scale affects the rendering result of the UI. The scale can be a non-integer value like 1.5.
+// Texts with a *text.GoTextFace are rendered at the scaled size, so they are crisp at any scale.
+// Texts with other faces like the default bitmap font face, a text.GoXFace, or a text.MultiFace are rendered at their own size and scaled.
+// Such texts are smoothed at a non-integer scale and look blurry. Use an integer scale for them to keep them crisp.
 //
-// The default scale is 1.
-func (c *Context) SetScale(scale int) {
-	if scale < 1 {
-		panic("debugui: scale must be >= 1")
+// The default scale is the device scale factor of the current monitor.
+//
+// SetScale panics if scale is not positive or not finite.
+func (c *Context) SetScale(scale float64) {
+	if scale <= 0 {
+		panic("debugui: scale must be > 0")
+	}
+	if math.IsInf(scale, 0) || math.IsNaN(scale) {
+		panic("debugui: scale must be finite")
 	}
-	c.scaleMinus1 = scale - 1
+	c.scale = scale
 }
 
 // Scale returns the scale of the UI.
-func (c *Context) Scale() int {
-	return c.scaleMinus1 + 1
+func (c *Context) Scale() float64 {
+	if c.scale > 0 {
+		return c.scale
+	}
+	if c.deviceScaleFactor > 0 {
+		return c.deviceScaleFactor
+	}
+	return 1
+}
+
+// updateDeviceScaleFactor updates the device scale factor used as the default scale.
+func (c *Context) updateDeviceScaleFactor() {
+	if c.scale > 0 {
+		return
+	}
+	if m := ebiten.Monitor(); m != nil {
+		c.deviceScaleFactor = m.DeviceScaleFactor()
+	}
+}
+
+// scaleRect scales the rectangle r in the UI coordinate into the screen coordinate.
+//
+// The edges are rounded in the same way, so adjacent rectangles are still adjacent after scaling.
+func scaleRect(r image.Rectangle, scale float64) image.Rectangle {
+	return image.Rectangle{
+		Min: scalePoint(r.Min, scale),
+		Max: scalePoint(r.Max, scale),
+	}
 }
 
-func (c *Context) style() *style {
-	return &defaultStyle
+func scalePoint(p image.Point, scale float64) image.Point {
+	return image.Pt(int(math.Round(float64(p.X)*scale)), int(math.Round(float64(p.Y)*scale)))
 }
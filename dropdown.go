@@ -15,7 +15,7 @@ import (
 // Returns an EventHandler that triggers when the selection changes.
 func (c *Context) Dropdown(selectedIndex *int, options []string) EventHandler {
 	pc := caller()
-	idPart := idPartFromCaller(pc)
+	idPart := c.nextIDPart(pc)
 	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
 		return c.dropdown(selectedIndex, options, idPart)
 	})
@@ -31,6 +31,7 @@ func (c *Context) dropdown(selectedIndex *int, options []string, idPart string)
 	last := *selectedIndex
 
 	id := c.idStack.push(idPart)
+	defer recordEdit(c, id, selectedIndex, last)
 	dropdownContainer := c.container(id, 0)
 
 	// Handle delayed closing of dropdown
@@ -90,7 +91,11 @@ func (c *Context) dropdown(selectedIndex *int, options []string, idPart string)
 			}
 		}
 
-		if c.pointing.justPressed() && c.focus == id {
+		// The gamepad's B button closes the dropdown.
+		if dropdownContainer.open && c.navCanceled() {
+			dropdownContainer.open = false
+			dropdownContainer.dropdownCloseDelay = 0
+		} else if (c.pointing.justPressed() && c.focus == id) || c.navActivated(id) {
 			if dropdownContainer.open {
 				// Close the dropdown immediately and cancel any pending delay
 				dropdownContainer.open = false
@@ -105,10 +110,10 @@ func (c *Context) dropdown(selectedIndex *int, options []string, idPart string)
 				if wasClosedBefore {
 					dropdownPos := image.Pt(bounds.Min.X, bounds.Max.Y)
 					buttonWidth := bounds.Dx()
-					optionHeight := c.style().defaultHeight + c.style().padding + 1
+					optionHeight := c.defaultHeight() + c.style().Padding + 1
 					totalHeight := len(options) * optionHeight
 
-					maxDropdownHeight := c.style().defaultHeight * 12 // around 10 items visible?
+					maxDropdownHeight := c.defaultHeight() * 12 // around 10 items visible?
 					actualHeight := min(totalHeight, maxDropdownHeight)
 
 					dropdownContainer.layout.Bounds = image.Rectangle{
@@ -118,24 +123,32 @@ func (c *Context) dropdown(selectedIndex *int, options []string, idPart string)
 				}
 			}
 		}
+		if c.navFocused(id) && !dropdownContainer.open {
+			if c.keyboard.keyRepeated(ebiten.KeyUp) && *selectedIndex > 0 {
+				*selectedIndex--
+			}
+			if c.keyboard.keyRepeated(ebiten.KeyDown) && *selectedIndex < len(options)-1 {
+				*selectedIndex++
+			}
+		}
 		if last != *selectedIndex {
 			e = &eventHandler{}
 		}
 
 		return e
 	}, func(bounds image.Rectangle) {
-		c.drawWidgetFrame(id, bounds, colorButton, optionAlignCenter)
+		c.drawWidgetFrame(id, bounds, ColorButton, optionAlignCenter)
 
 		arrowWidth := bounds.Dy()
 		textBounds := bounds
 		textBounds.Max.X -= arrowWidth
-		c.drawWidgetText(options[*selectedIndex], textBounds, colorText, optionAlignCenter)
+		c.drawWidgetText(options[*selectedIndex], textBounds, ColorText, optionAlignCenter)
 
 		arrowBounds := image.Rect(bounds.Max.X-arrowWidth, bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
-		icon := iconDown
+		icon := IconDown
 		if c.container(id, 0).open {
-			icon = iconUp
+			icon = IconUp
 		}
-		c.drawIcon(icon, arrowBounds, c.style().colors[colorText])
+		c.drawIcon(icon, arrowBounds, c.style().Colors[ColorText])
 	})
 }
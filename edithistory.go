@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// maxEditRecords is the maximum number of the edits kept in the edit history.
+const maxEditRecords = 100
+
+// EditRecord represents an edit of a widget's value recorded in the edit history.
+type EditRecord struct {
+	// Path is the ID path of the edited widget. See also [WidgetInfo].
+	Path string
+
+	// Before is the value before the edit.
+	Before any
+
+	// After is the value after the edit.
+	After any
+}
+
+type editRecord struct {
+	EditRecord
+
+	set func(value any)
+}
+
+type editHistory struct {
+	enabled bool
+	records []editRecord
+
+	// applied is the number of the records that are applied. The records after applied can be redone.
+	applied int
+
+	// groupID is the ID of the widget whose continuous edits are merged into the last record.
+	groupID widgetID
+}
+
+// SetEditHistoryEnabled enables or disables the edit history. The edit history is disabled by default.
+//
+// While the edit history is enabled, the edits of the values by sliders, number fields, checkboxes, dropdowns,
+// text fields and text areas are recorded.
+// Continuous edits like dragging a slider or typing in a text field until the edit is confirmed are recorded as one edit.
+// The edits can be undone and redone by Ctrl+Z and Ctrl+Y (Ctrl+Shift+Z), or by [Context.Undo] and [Context.Redo].
+//
+// The recorded edits keep the pointers to the values bound to the widgets, and [Context.Undo] and [Context.Redo] write to them later.
+// Thus, the bound values must outlive the frame. Don't bind a temporary variable like a local copy of a field.
+//
+// Disabling the edit history clears the recorded edits.
+func (c *Context) SetEditHistoryEnabled(enabled bool) {
+	if c.editHistory.enabled == enabled {
+		return
+	}
+	c.editHistory = editHistory{
+		enabled: enabled,
+	}
+}
+
+// Undo restores the value of the last applied edit in the edit history.
+//
+// Undo returns false if there is nothing to undo, or an edit is in progress, e.g. a slider is being dragged.
+//
+// Undo writes to the value bound to the edited widget, so the value must still be alive.
+func (c *Context) Undo() bool {
+	h := &c.editHistory
+	if h.applied == 0 || h.groupID != (widgetID{}) {
+		return false
+	}
+	h.applied--
+	r := h.records[h.applied]
+	r.set(r.Before)
+	return true
+}
+
+// Redo applies the edit that was undone last in the edit history again.
+//
+// Redo returns false if there is nothing to redo, or an edit is in progress.
+func (c *Context) Redo() bool {
+	h := &c.editHistory
+	if h.applied == len(h.records) || h.groupID != (widgetID{}) {
+		return false
+	}
+	r := h.records[h.applied]
+	h.applied++
+	r.set(r.After)
+	return true
+}
+
+// History returns the edits in the edit history from the oldest to the newest,
+// and the number of the edits that are applied.
+//
+// The first applied edits can be undone, and the rest can be redone.
+func (c *Context) History() (records []EditRecord, applied int) {
+	records = make([]EditRecord, 0, len(c.editHistory.records))
+	for _, r := range c.editHistory.records {
+		records = append(records, r.EditRecord)
+	}
+	return records, c.editHistory.applied
+}
+
+// recordEdit records the edit of the widget's value from before to *value in the edit history.
+//
+// While the widget keeps the focus, its edits are merged into one record.
+func recordEdit[T comparable](c *Context, id widgetID, value *T, before T) {
+	h := &c.editHistory
+	if !h.enabled || *value == before {
+		return
+	}
+	after := *value
+
+	if h.groupID == id && h.groupID != (widgetID{}) && h.applied > 0 {
+		r := &h.records[h.applied-1]
+		r.After = after
+		// Editing the value back to the original one cancels the edit.
+		if r.Before.(T) == after {
+			h.records = h.records[:h.applied-1]
+			h.applied--
+			h.groupID = widgetID{}
+		}
+		return
+	}
+
+	// A new edit discards the edits that can be redone.
+	h.records = append(h.records[:h.applied], editRecord{
+		EditRecord: EditRecord{
+			Path:   c.widgetPath(id),
+			Before: before,
+			After:  after,
+		},
+		set: func(v any) {
+			*value = v.(T)
+		},
+	})
+	if len(h.records) > maxEditRecords {
+		h.records = h.records[len(h.records)-maxEditRecords:]
+	}
+	h.applied = len(h.records)
+	if c.focus == id {
+		h.groupID = id
+	} else {
+		h.groupID = widgetID{}
+	}
+}
+
+// withoutEditHistory calls f without recording the edits.
+//
+// This is used when a widget edits a temporary value, and the caller records the edit of the long-lived value instead.
+func (c *Context) withoutEditHistory(f func()) {
+	enabled := c.editHistory.enabled
+	c.editHistory.enabled = false
+	defer func() {
+		c.editHistory.enabled = enabled
+	}()
+	f()
+}
+
+// endEditGroup stops merging the edits into the last record, e.g. when a text field's value is confirmed.
+func (c *Context) endEditGroup() {
+	c.editHistory.groupID = widgetID{}
+}
+
+// updateEditHistory handles Ctrl+Z and Ctrl+Y, and stops merging the edits when the edited widget loses the focus.
+func (c *Context) updateEditHistory() {
+	if c.focus != c.editHistory.groupID {
+		c.endEditGroup()
+	}
+	// Ctrl+Z and Ctrl+Y are not handled while a widget like a text field has the focus, or a modal window is open.
+	if !c.editHistory.enabled || c.focus != (widgetID{}) || c.modalRootContainer() != nil {
+		return
+	}
+	if !c.keyboard.isKeyPressed(ebiten.KeyControl) && !c.keyboard.isKeyPressed(ebiten.KeyMeta) {
+		return
+	}
+	switch {
+	case c.keyboard.keyRepeated(ebiten.KeyZ) && c.keyboard.isKeyPressed(ebiten.KeyShift):
+		c.Redo()
+	case c.keyboard.keyRepeated(ebiten.KeyZ):
+		c.Undo()
+	case c.keyboard.keyRepeated(ebiten.KeyY):
+		c.Redo()
+	}
+}
+
+// widgetPath returns the ID path of the widget drawn last with the given ID in the current root container.
+func (c *Context) widgetPath(id widgetID) string {
+	cnt := c.currentRootContainer()
+	if cnt == nil {
+		return ""
+	}
+	for i := len(cnt.widgetInfos) - 1; i >= 0; i-- {
+		if info := &cnt.widgetInfos[i]; info.id == id {
+			return info.path()
+		}
+	}
+	return ""
+}
@@ -29,6 +29,8 @@ type Game struct {
 	vx                int
 	vy                int
 	hiRes             bool
+	showThemeEditor   bool
+	showPerfWindow    bool
 	needResetPosition bool
 	screenWidth       int
 	screenHeight      int
@@ -39,7 +41,7 @@ type Game struct {
 	logBuf       string
 	logSubmitBuf string
 	logUpdated   bool
-	bg           [3]int
+	bg           color.RGBA
 	checks       [3]bool
 	num1_1       int
 	num1_2       int
@@ -48,6 +50,13 @@ type Game struct {
 	num3_2       float64
 	num4         float64
 	num5         int
+	notes        string
+	xHistory     [120]float64
+	yHistory     [120]float64
+	historyIndex int
+
+	difficulty int
+	speedMode  int
 
 	selectedOption1, selectedOption2   int
 	dropdownOptions1, dropdownOptions2 []string
@@ -63,7 +72,7 @@ func NewGame() (*Game, error) {
 		gopherImage:       ebiten.NewImageFromImage(img),
 		vx:                2,
 		vy:                2,
-		bg:                [3]int{90, 95, 100},
+		bg:                color.RGBA{90, 95, 100, 255},
 		checks:            [3]bool{true, false, true},
 		needResetPosition: true,
 	}
@@ -97,14 +106,26 @@ func (g *Game) Update() error {
 	if g.y < 0 || sH-imgH <= g.y {
 		g.vy *= -1
 	}
+	g.xHistory[g.historyIndex] = float64(g.x)
+	g.yHistory[g.historyIndex] = float64(g.y)
+	g.historyIndex = (g.historyIndex + 1) % len(g.xHistory)
 
 	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
 		return ebiten.Termination
 	}
 	inputCaptured, err := g.debugUI.Update(func(ctx *debugui.Context) error {
+		// The screen size depends on the scale. See Layout.
+		ctx.SetScale(g.scale())
+		ctx.SetEditHistoryEnabled(true)
 		g.testWindow(ctx)
 		g.logWindow(ctx)
 		g.buttonWindows(ctx)
+		if g.showThemeEditor {
+			ctx.ThemeEditorWindow()
+		}
+		if g.showPerfWindow {
+			ctx.PerfWindow()
+		}
 		return nil
 	})
 	if err != nil {
@@ -132,12 +153,15 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	g.debugUI.Draw(screen)
 }
 
-func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	scale := 1
+func (g *Game) scale() float64 {
 	if g.hiRes {
-		scale = 2
+		return 2
 	}
-	sw, sh := outsideWidth*scale, outsideHeight*scale
+	return 1
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	sw, sh := int(float64(outsideWidth)*g.scale()), int(float64(outsideHeight)*g.scale())
 	if sw != g.screenWidth || sh != g.screenHeight {
 		g.screenWidth = sw
 		g.screenHeight = sh
@@ -6,12 +6,9 @@ package main
 import (
 	"fmt"
 	"image"
-	"image/color"
 
 	"github.com/ebitengine/debugui"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/text/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 func (g *Game) writeLog(text string) {
@@ -24,6 +21,33 @@ func (g *Game) writeLog(text string) {
 
 func (g *Game) testWindow(ctx *debugui.Context) {
 	ctx.Window("Demo Window", image.Rect(40, 40, 340, 500), func(layout debugui.ContainerLayout) {
+		ctx.MenuBar(func() {
+			ctx.Menu("Game", func() {
+				ctx.MenuItem("Reset Position", "").On(func() {
+					g.needResetPosition = true
+				})
+				ctx.Menu("Log", func() {
+					ctx.MenuItem("Write Position", "").On(func() {
+						g.writeLog(fmt.Sprintf("Position: %d, %d", g.x, g.y))
+					})
+					ctx.MenuItem("Clear", "").On(func() {
+						g.logBuf = ""
+					})
+				})
+			})
+			ctx.Menu("View", func() {
+				ctx.MenuCheckItem(&g.showThemeEditor, "Theme Editor", "")
+				ctx.MenuCheckItem(&g.showPerfWindow, "Performance", "")
+			})
+			ctx.Menu("Edit", func() {
+				ctx.MenuItem("Undo", "Ctrl+Z").On(func() {
+					ctx.Undo()
+				})
+				ctx.MenuItem("Redo", "Ctrl+Y").On(func() {
+					ctx.Redo()
+				})
+			})
+		})
 		ctx.Header("Window Info", false, func() {
 			ctx.SetGridLayout([]int{-1, -1}, nil)
 			ctx.Text("Position:")
@@ -33,13 +57,12 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 		})
 		ctx.Header("Game Config", true, func() {
 			ctx.Checkbox(&g.hiRes, "Hi-Res").On(func() {
-				if g.hiRes {
-					ctx.SetScale(2)
-				} else {
-					ctx.SetScale(1)
-				}
 				g.needResetPosition = true
 			})
+			ctx.Tooltip("Render the screen at the device scale factor")
+			ctx.Checkbox(&g.showThemeEditor, "Theme Editor")
+			ctx.Checkbox(&g.showPerfWindow, "Performance")
+			ctx.Tooltip("Show TPS, FPS, memory statistics and the cost of the debug UI")
 		})
 		ctx.Header("Test Buttons", true, func() {
 			ctx.SetGridLayout([]int{-2, -1, -1}, nil)
@@ -47,9 +70,15 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 			ctx.Button("Button 1").On(func() {
 				g.writeLog("Pressed button 1")
 			})
+			ctx.DoubleClicked().On(func() {
+				g.writeLog("Double-clicked button 1")
+			})
 			ctx.Button("Button 2").On(func() {
 				g.writeLog("Pressed button 2")
 			})
+			ctx.SecondaryClicked().On(func() {
+				g.writeLog("Secondary-clicked button 2")
+			})
 			ctx.Text("Test buttons 2:")
 			ctx.Button("Button 3").On(func() {
 				g.writeLog("Pressed button 3")
@@ -78,6 +107,15 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 				g.writeLog(fmt.Sprintf("Selected another option: %s", g.dropdownOptions2[g.selectedOption2]))
 			})
 		})
+		ctx.Header("Radio Buttons", false, func() {
+			difficulties := []string{"Easy", "Normal", "Hard"}
+			ctx.RadioGroup(&g.difficulty, difficulties, true).On(func() {
+				g.writeLog(fmt.Sprintf("Selected difficulty: %s", difficulties[g.difficulty]))
+			})
+			ctx.SetGridLayout([]int{-1}, nil)
+			ctx.RadioButton(&g.speedMode, 0, "Fixed Speed")
+			ctx.RadioButton(&g.speedMode, 1, "Variable Speed")
+		})
 
 		ctx.Header("Tree and Text", true, func() {
 			ctx.SetGridLayout([]int{-1, -1}, nil)
@@ -132,45 +170,83 @@ func (g *Game) testWindow(ctx *debugui.Context) {
 			})
 		})
 		ctx.Header("Color", true, func() {
-			ctx.SetGridLayout([]int{-3, -1}, []int{54})
-			ctx.GridCell(func(bounds image.Rectangle) {
-				ctx.SetGridLayout([]int{-1, -3}, nil)
-				ctx.Text("Red:")
-				ctx.Slider(&g.bg[0], 0, 255, 1)
-				ctx.Text("Green:")
-				ctx.Slider(&g.bg[1], 0, 255, 1)
-				ctx.Text("Blue:")
-				ctx.Slider(&g.bg[2], 0, 255, 1)
-			})
-			ctx.GridCell(func(bounds image.Rectangle) {
-				ctx.DrawOnlyWidget(func(screen *ebiten.Image) {
-					scale := ctx.Scale()
-					vector.DrawFilledRect(
-						screen,
-						float32(bounds.Min.X*scale),
-						float32(bounds.Min.Y*scale),
-						float32(bounds.Dx()*scale),
-						float32(bounds.Dy()*scale),
-						color.RGBA{byte(g.bg[0]), byte(g.bg[1]), byte(g.bg[2]), 255},
-						false)
-					txt := fmt.Sprintf("#%02X%02X%02X", int(g.bg[0]), int(g.bg[1]), int(g.bg[2]))
-					op := &text.DrawOptions{}
-					op.GeoM.Translate(float64((bounds.Min.X+bounds.Max.X)/2), float64((bounds.Min.Y+bounds.Max.Y)/2))
-					op.GeoM.Scale(float64(scale), float64(scale))
-					op.PrimaryAlign = text.AlignCenter
-					op.SecondaryAlign = text.AlignCenter
-					debugui.DrawText(screen, txt, op)
-				})
+			ctx.SetGridLayout([]int{-1, -3}, nil)
+			ctx.Text("Background:")
+			ctx.ColorEdit(&g.bg).On(func() {
+				g.writeLog(fmt.Sprintf("Changed background: R: %d, G: %d, B: %d, A: %d", g.bg.R, g.bg.G, g.bg.B, g.bg.A))
 			})
 		})
 		ctx.Header("Number", true, func() {
 			ctx.NumberField(&g.num1_1, 1)
 			ctx.NumberField(&g.num1_2, 1)
 			ctx.Slider(&g.num2, 0, 1000, 10)
+			ctx.ContextMenu(func() {
+				ctx.MenuItem("Reset to Zero", "").On(func() {
+					g.num2 = 0
+				})
+			})
 			ctx.NumberFieldF(&g.num3_1, 0.1, 2)
 			ctx.NumberFieldF(&g.num3_2, 0.1, 2)
 			ctx.SliderF(&g.num4, 0, 10, 0.1, 2)
 			ctx.Slider(&g.num5, 0, 2, 1)
+			ctx.SetGridLayout([]int{-1, -1, -1}, nil)
+			ctx.Button("Undo").On(func() {
+				ctx.Undo()
+			})
+			ctx.Button("Redo").On(func() {
+				ctx.Redo()
+			})
+			resetID, chosen := ctx.Confirm("Reset All Tunables?", "All the numbers are reset to zero.", "Reset", "Cancel")
+			if chosen == 0 {
+				g.num1_1, g.num1_2, g.num2 = 0, 0, 0
+				g.num3_1, g.num3_2, g.num4 = 0, 0, 0
+				g.num5 = 0
+				g.writeLog("Reset all tunables")
+			}
+			ctx.Button("Reset").On(func() {
+				ctx.OpenModal(resetID)
+			})
+		})
+		ctx.Header("Plot", false, func() {
+			series := []debugui.PlotSeries{
+				{Label: "x", Values: g.xHistory[:], Start: g.historyIndex},
+				{Label: "y", Values: g.yHistory[:], Start: g.historyIndex},
+			}
+			ctx.SetGridLayout([]int{-1}, []int{80, 80})
+			ctx.Plot(series, nil)
+			ctx.Plot(series[:1], &debugui.PlotOptions{
+				Style: debugui.PlotStyleBar,
+				Min:   0,
+				Max:   float64(g.screenWidth),
+			})
+		})
+		ctx.Header("Tab Bar", false, func() {
+			tabBarID := ctx.TabBar(func() {
+				ctx.Tab("Position", func() {
+					ctx.SetGridLayout([]int{-1, -1}, nil)
+					ctx.Text("X:")
+					ctx.Text(fmt.Sprintf("%d", g.x))
+					ctx.Text("Y:")
+					ctx.Text(fmt.Sprintf("%d", g.y))
+				})
+				ctx.Tab("Velocity", func() {
+					ctx.SetGridLayout([]int{-1, -1}, nil)
+					ctx.Text("X:")
+					ctx.Text(fmt.Sprintf("%d", g.vx))
+					ctx.Text("Y:")
+					ctx.Text(fmt.Sprintf("%d", g.vy))
+				})
+				ctx.Tab("Background", func() {
+					ctx.Text(fmt.Sprintf("R: %d, G: %d, B: %d", g.bg.R, g.bg.G, g.bg.B))
+				})
+			})
+			ctx.Button("Show Position").On(func() {
+				ctx.SelectTab(tabBarID, 0)
+			})
+		})
+		ctx.Header("Text Area", false, func() {
+			ctx.SetGridLayout(nil, []int{80})
+			ctx.TextArea(&g.notes)
 		})
 		ctx.Header("Licenses", false, func() {
 			ctx.Text(`The photograph by Chris Nokleberg is licensed under the Creative Commons Attribution 4.0 License
@@ -16,7 +16,7 @@ import "image"
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
 func (c *Context) Header(label string, initialExpansion bool, f func()) {
 	pc := caller()
-	id := c.idStack.push(idPartFromCaller(pc))
+	id := c.idStack.push(c.nextIDPart(pc))
 	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
 		var opt option
 		if initialExpansion {
@@ -39,7 +39,7 @@ func (c *Context) Header(label string, initialExpansion bool, f func()) {
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
 func (c *Context) TreeNode(label string, f func()) {
 	pc := caller()
-	id := c.idStack.push(idPartFromCaller(pc))
+	id := c.idStack.push(c.nextIDPart(pc))
 	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
 		if err := c.treeNode(label, 0, id, f); err != nil {
 			return nil, err
@@ -51,6 +51,12 @@ func (c *Context) TreeNode(label string, f func()) {
 func (c *Context) header(label string, isTreeNode bool, opt option, id widgetID, f func() error) error {
 	c.SetGridLayout(nil, nil)
 
+	key := label
+	if k, ok := keyFromID(id); ok {
+		key = k
+	}
+	path := c.restoreHeaderExpansion(key, id, (opt&optionExpanded) != 0)
+
 	var expanded bool
 	toggled := c.currentContainer().toggled(id)
 	if (opt & optionExpanded) != 0 {
@@ -60,7 +66,7 @@ func (c *Context) header(label string, isTreeNode bool, opt option, id widgetID,
 	}
 
 	e, err := c.widget(id, 0, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
-		if c.pointing.justPressed() && c.focus == id {
+		if (c.pointing.justPressed() && c.focus == id) || c.navActivated(id) {
 			c.currentContainer().toggle(id)
 		}
 		if expanded {
@@ -70,30 +76,33 @@ func (c *Context) header(label string, isTreeNode bool, opt option, id widgetID,
 	}, func(bounds image.Rectangle) {
 		if isTreeNode {
 			if c.hover == id {
-				c.drawFrame(bounds, colorButtonHover)
+				c.drawFrame(bounds, ColorButtonHover)
 			}
 		} else {
-			c.drawWidgetFrame(id, bounds, colorButton, 0)
+			c.drawWidgetFrame(id, bounds, ColorButton, 0)
 		}
-		var icon icon
+		var icon Icon
 		if expanded {
-			icon = iconExpanded
+			icon = IconExpanded
 		} else {
-			icon = iconCollapsed
+			icon = IconCollapsed
 		}
 		c.drawIcon(
 			icon,
 			image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Min.X+bounds.Dy(), bounds.Max.Y),
-			c.style().colors[colorText],
+			c.style().Colors[ColorText],
 		)
-		bounds.Min.X += bounds.Dy() - c.style().padding
-		c.drawWidgetText(label, bounds, colorText, 0)
+		bounds.Min.X += bounds.Dy() - c.style().Padding
+		c.drawWidgetText(label, bounds, ColorText, 0)
 	})
 	if err != nil {
 		return err
 	}
+	c.recordHeaderExpansion(path, c.currentContainer().toggled(id) != ((opt&optionExpanded) != 0))
 	if e != nil {
 		e.On(func() {
+			c.pushKeyPath(key)
+			defer c.popKeyPath()
 			if err := f(); err != nil && c.err == nil {
 				c.err = err
 			}
@@ -108,14 +117,14 @@ func (c *Context) treeNode(label string, opt option, id widgetID, f func()) erro
 		if err != nil {
 			return err
 		}
-		l.indent += c.style().indent
+		l.indent += c.indent()
 		defer func() {
 			l, err2 := c.layout()
 			if err2 != nil && err == nil {
 				err = err2
 				return
 			}
-			l.indent -= c.style().indent
+			l.indent -= c.indent()
 		}()
 		f()
 		return nil
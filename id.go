@@ -6,6 +6,8 @@ package debugui
 import (
 	"fmt"
 	"runtime"
+	"strconv"
+	"strings"
 )
 
 // caller returns a program counter of the caller.
@@ -20,15 +22,19 @@ func caller() uintptr {
 
 // Loop creates a loop to iterate by the given count.
 // Loop creates a unique ID scope for each iteration.
+//
+// The index of each iteration is a part of the ID paths of the widgets in it, like "Demo Window/0/Button".
 func (c *Context) Loop(count int, f func(i int)) {
 	pc := caller()
-	c.idStack = c.idStack.push(idPartFromCaller(pc))
+	c.idStack = c.idStack.push(c.nextIDPart(pc))
 	defer func() {
 		c.idStack = c.idStack.pop()
 	}()
 	for i := range count {
 		c.idStack = c.idStack.push(idPartFromInt(i))
+		c.pushKeyPath(strconv.Itoa(i))
 		f(i)
+		c.popKeyPath()
 		c.idStack = c.idStack.pop()
 	}
 }
@@ -38,17 +44,64 @@ func (c *Context) Loop(count int, f func(i int)) {
 //
 // IDScope is useful when you want to create multiple widgets at the same position e.g. in a for loop.
 //
+// The name is a part of the ID paths of the widgets in the scope, like "Demo Window/Player/Button".
+//
 // IDScope is a low level API. For a simple loop, use [Loop] instead.
 func (c *Context) IDScope(name string, f func()) {
 	pc := caller()
-	c.idStack = c.idStack.push(idPartFromCaller(pc))
+	c.idStack = c.idStack.push(c.nextIDPart(pc))
 	c.idStack = c.idStack.push(idPartFromString(name))
+	c.pushKeyPath(name)
 	defer func() {
+		c.popKeyPath()
 		c.idStack = c.idStack.pop().pop()
 	}()
 	f()
 }
 
+// SetNextKey sets the key of the next window or widget.
+//
+// A key is an explicit string to identify a window or a widget instead of its call location.
+// The ID of a keyed window or widget doesn't change when the code is modified, as long as the IDs of its parents don't change.
+// A key must be unique among the siblings, and must not contain '/'. Otherwise, Update returns an error.
+//
+// A key is also used as a part of ID paths like "Demo Window/Game Config/Hi-Res". See also [WidgetInfo].
+// The key of a window is used to save the state of the window instead of the title. See also [DebugUI.SaveState].
+//
+// The key applies to the next function call that creates a window or a widget with an ID, e.g., not [Context.Text].
+func (c *Context) SetNextKey(key string) {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		if strings.Contains(key, "/") {
+			return nil, fmt.Errorf("debugui: key must not contain '/': %q", key)
+		}
+		c.nextKey = key
+		return nil, nil
+	})
+}
+
+// nextIDPart returns an ID part for a new window or widget.
+//
+// nextIDPart returns an ID part from the key set by SetNextKey if any, or from the caller PC otherwise.
+func (c *Context) nextIDPart(callerPC uintptr) string {
+	if key := c.nextKey; key != "" {
+		c.nextKey = ""
+		return idPartFromKey(key)
+	}
+	return idPartFromCaller(callerPC)
+}
+
+// keyFromID returns the key of the widget ID, if the ID was created with a key.
+func keyFromID(id widgetID) (string, bool) {
+	if id.size == 0 {
+		return "", false
+	}
+	key, ok := strings.CutPrefix(id.idParts[id.size-1], theKeyIDCache.prefix+":")
+	if !ok {
+		return "", false
+	}
+	return key, true
+}
+
 func (c *Context) idScopeFromIDPart(idPart string, f func(id widgetID)) {
 	c.idStack = c.idStack.push(idPart)
 	defer func() {
@@ -57,10 +110,40 @@ func (c *Context) idScopeFromIDPart(idPart string, f func(id widgetID)) {
 	f(c.idStack)
 }
 
+// keyPathString returns the path of the key in the current key path like "Demo Window/Game Config".
+func (c *Context) keyPathString(key string) string {
+	parent := c.currentKeyPath()
+	if parent == "" {
+		return key
+	}
+	return parent + "/" + key
+}
+
+// currentKeyPath returns the current key path joined with '/'.
+func (c *Context) currentKeyPath() string {
+	if len(c.keyPath) == 0 {
+		return ""
+	}
+	return c.keyPath[len(c.keyPath)-1]
+}
+
+func (c *Context) pushKeyPath(key string) {
+	// Keep the joined path so that the path is not joined for each widget.
+	c.keyPath = append(c.keyPath, c.keyPathString(key))
+}
+
+func (c *Context) popKeyPath() {
+	c.keyPath = c.keyPath[:len(c.keyPath)-1]
+}
+
 func idPartFromString(str string) string {
 	return theStringIDCache.get(str)
 }
 
+func idPartFromKey(key string) string {
+	return theKeyIDCache.get(key)
+}
+
 func idPartFromInt(i int) string {
 	return theIntIDCache.get(i)
 }
@@ -90,4 +173,5 @@ var (
 	theStringIDCache = idCache[string]{prefix: "string"}
 	theIntIDCache    = idCache[int]{prefix: "number"}
 	theCallerIDCache = idCache[uintptr]{prefix: "caller"}
+	theKeyIDCache    = idCache[string]{prefix: "key"}
 )
@@ -5,23 +5,151 @@ package debugui
 
 import (
 	"image"
+	"math"
 	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// InputSource is a source of input for the debug UI.
+//
+// By default, the debug UI reads input from Ebitengine directly.
+// An InputSource is useful to drive the debug UI with synthetic input, e.g. in tests.
+//
+// All the positions are in the screen coordinate, i.e., not divided by the UI scale.
+type InputSource interface {
+	// CursorPosition returns the position of the mouse cursor.
+	CursorPosition() (x, y int)
+
+	// IsMouseButtonPressed reports whether the mouse button is pressed.
+	IsMouseButtonPressed(button ebiten.MouseButton) bool
+
+	// AppendTouchIDs appends the IDs of the current touches to touches, and returns the extended slice.
+	AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID
+
+	// TouchPosition returns the position of the touch.
+	TouchPosition(id ebiten.TouchID) (x, y int)
+
+	// Wheel returns the offsets of the mouse wheel in the current tick.
+	Wheel() (xoff, yoff float64)
+
+	// IsKeyPressed reports whether the key is pressed.
+	IsKeyPressed(key ebiten.Key) bool
+
+	// AppendInputChars appends the runes input in the current tick to runes, and returns the extended slice.
+	AppendInputChars(runes []rune) []rune
+}
+
+// GamepadInputSource is an InputSource that also provides input from gamepads with the standard layout.
+//
+// If an InputSource doesn't implement GamepadInputSource, the debug UI doesn't read gamepads.
+type GamepadInputSource interface {
+	InputSource
+
+	// AppendGamepadIDs appends the IDs of the connected gamepads to gamepadIDs, and returns the extended slice.
+	AppendGamepadIDs(gamepadIDs []ebiten.GamepadID) []ebiten.GamepadID
+
+	// IsStandardGamepadButtonPressed reports whether the button of the gamepad is pressed.
+	IsStandardGamepadButtonPressed(id ebiten.GamepadID, button ebiten.StandardGamepadButton) bool
+
+	// StandardGamepadAxisValue returns the value of the axis of the gamepad in the range [-1, 1].
+	StandardGamepadAxisValue(id ebiten.GamepadID, axis ebiten.StandardGamepadAxis) float64
+}
+
+// ebitenInputSource is the default InputSource reading input from Ebitengine.
+type ebitenInputSource struct{}
+
+func (ebitenInputSource) CursorPosition() (x, y int) {
+	return ebiten.CursorPosition()
+}
+
+func (ebitenInputSource) IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	return ebiten.IsMouseButtonPressed(button)
+}
+
+func (ebitenInputSource) AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID {
+	return ebiten.AppendTouchIDs(touches)
+}
+
+func (ebitenInputSource) TouchPosition(id ebiten.TouchID) (x, y int) {
+	return ebiten.TouchPosition(id)
+}
+
+func (ebitenInputSource) Wheel() (xoff, yoff float64) {
+	return ebiten.Wheel()
+}
+
+func (ebitenInputSource) IsKeyPressed(key ebiten.Key) bool {
+	return ebiten.IsKeyPressed(key)
+}
+
+func (ebitenInputSource) AppendInputChars(runes []rune) []rune {
+	return ebiten.AppendInputChars(runes)
+}
+
+func (ebitenInputSource) AppendGamepadIDs(gamepadIDs []ebiten.GamepadID) []ebiten.GamepadID {
+	return ebiten.AppendGamepadIDs(gamepadIDs)
+}
+
+func (ebitenInputSource) IsStandardGamepadButtonPressed(id ebiten.GamepadID, button ebiten.StandardGamepadButton) bool {
+	return ebiten.IsStandardGamepadButtonPressed(id, button)
+}
+
+func (ebitenInputSource) StandardGamepadAxisValue(id ebiten.GamepadID, axis ebiten.StandardGamepadAxis) float64 {
+	return ebiten.StandardGamepadAxisValue(id, axis)
+}
+
+func (c *Context) inputSource() InputSource {
+	if c.input == nil {
+		return ebitenInputSource{}
+	}
+	return c.input
+}
+
+// usesEbitenInputSource reports whether the input is read from Ebitengine directly.
+//
+// Text input with an IME is available only in this case.
+func (c *Context) usesEbitenInputSource() bool {
+	_, ok := c.inputSource().(ebitenInputSource)
+	return ok
+}
+
+const (
+	// doubleClickDistance is the maximum distance between the positions of the two presses of a double click.
+	doubleClickDistance = 4
+
+	// longPressDistance is the maximum distance a touch can move during a long press.
+	longPressDistance = 8
 )
 
 type pointing struct {
-	justPressedTouchIDs []ebiten.TouchID
-	touchIDs            []ebiten.TouchID
-	hasPrimaryTouchID   bool
-	primaryTouchID      ebiten.TouchID
-	duration            int
+	touchIDs          []ebiten.TouchID
+	prevTouchIDs      []ebiten.TouchID
+	hasPrimaryTouchID bool
+	primaryTouchID    ebiten.TouchID
+	mouseDurations    [ebiten.MouseButtonMax + 1]int
+	pos               image.Point
+	wheelX            float64
+	wheelY            float64
+	duration          int
+
+	// ticks is the number of the updates.
+	ticks int
+
+	// pressTicks and pressPos are the ticks and the position of the last press.
+	pressTicks int
+	pressPos   image.Point
+
+	// pressMoved reports whether the pointing device moved away from pressPos while it is pressed.
+	pressMoved bool
+
+	// clickCount is the number of the successive presses at the same position, e.g., 2 for a double click.
+	clickCount int
 }
 
-func (p *pointing) update() {
-	p.justPressedTouchIDs = inpututil.AppendJustPressedTouchIDs(p.justPressedTouchIDs[:0])
-	p.touchIDs = ebiten.AppendTouchIDs(p.touchIDs[:0])
+func (p *pointing) update(src InputSource, doubleClickTicks int) {
+	p.prevTouchIDs = append(p.prevTouchIDs[:0], p.touchIDs...)
+	p.touchIDs = src.AppendTouchIDs(p.touchIDs[:0])
 
 	if len(p.touchIDs) == 0 {
 		p.hasPrimaryTouchID = false
@@ -31,11 +159,46 @@ func (p *pointing) update() {
 		p.primaryTouchID = p.touchIDs[0]
 	}
 
+	for button := range p.mouseDurations {
+		if src.IsMouseButtonPressed(ebiten.MouseButton(button)) {
+			p.mouseDurations[button]++
+		} else {
+			p.mouseDurations[button] = 0
+		}
+	}
+
+	if p.isTouchActive() {
+		p.pos = image.Pt(src.TouchPosition(p.primaryTouchID))
+	} else {
+		p.pos = image.Pt(src.CursorPosition())
+	}
+	p.wheelX, p.wheelY = src.Wheel()
+
 	if p.pressed() {
 		p.duration++
 	} else {
 		p.duration = 0
 	}
+
+	p.ticks++
+	if p.justPressed() {
+		if p.clickCount > 0 && p.ticks-p.pressTicks <= doubleClickTicks && near(p.pos, p.pressPos, doubleClickDistance) {
+			p.clickCount++
+		} else {
+			p.clickCount = 1
+		}
+		p.pressTicks = p.ticks
+		p.pressPos = p.pos
+		p.pressMoved = false
+	} else if p.pressed() && !near(p.pos, p.pressPos, longPressDistance) {
+		p.pressMoved = true
+	}
+}
+
+// near reports whether the distance between a and b is d or less in both directions.
+func near(a, b image.Point, d int) bool {
+	v := a.Sub(b)
+	return max(v.X, -v.X) <= d && max(v.Y, -v.Y) <= d
 }
 
 func (p *pointing) isTouchActive() bool {
@@ -46,32 +209,159 @@ func (p *pointing) isTouchActive() bool {
 }
 
 func (p *pointing) position() image.Point {
-	if p.isTouchActive() {
-		return image.Pt(ebiten.TouchPosition(p.primaryTouchID))
-	}
-	return image.Pt(ebiten.CursorPosition())
+	return p.pos
 }
 
 func (p *pointing) pressed() bool {
 	if p.isTouchActive() {
 		return true
 	}
-	return ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	return p.mouseDurations[ebiten.MouseButtonLeft] > 0
 }
 
 func (p *pointing) justPressed() bool {
 	if p.isTouchActive() {
-		return slices.Contains(p.justPressedTouchIDs, p.primaryTouchID)
+		return !slices.Contains(p.prevTouchIDs, p.primaryTouchID)
+	}
+	return p.mouseDurations[ebiten.MouseButtonLeft] == 1
+}
+
+// buttonJustPressed reports whether the mouse button is just pressed.
+func (p *pointing) buttonJustPressed(button ebiten.MouseButton) bool {
+	return p.mouseDurations[button] == 1
+}
+
+// secondaryJustPressed reports whether the right mouse button is just pressed, or the touch is just long-pressed.
+func (p *pointing) secondaryJustPressed() bool {
+	return p.buttonJustPressed(ebiten.MouseButtonRight) || p.longPressed()
+}
+
+// doubleClicked reports whether the pointing device is just pressed as the second press of a double click.
+func (p *pointing) doubleClicked() bool {
+	return p.justPressed() && p.clickCount == 2
+}
+
+// longPressed reports whether the touch is just long-pressed without moving.
+func (p *pointing) longPressed() bool {
+	return p.isTouchActive() && p.duration == longPressTicks() && !p.pressMoved
+}
+
+// longPressTicks returns the number of ticks to hold a touch for a long press.
+func longPressTicks() int {
+	return tps() / 2
+}
+
+// tps returns the number of ticks per second.
+//
+// If the TPS is synchronized with the FPS by ebiten.SyncWithFPS, tps assumes the default TPS.
+func tps() int {
+	if t := ebiten.TPS(); t > 0 {
+		return t
 	}
-	return inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+	return ebiten.DefaultTPS
 }
 
 func (p *pointing) repeated() bool {
 	return repeated(p.duration)
 }
 
-func keyRepeated(key ebiten.Key) bool {
-	return repeated(inpututil.KeyPressDuration(key))
+func (p *pointing) wheel() (xoff, yoff float64) {
+	return p.wheelX, p.wheelY
+}
+
+type keyboard struct {
+	durations [ebiten.KeyMax + 1]int
+	runes     []rune
+}
+
+func (k *keyboard) update(src InputSource) {
+	for key := ebiten.Key(0); key <= ebiten.KeyMax; key++ {
+		if src.IsKeyPressed(key) {
+			k.durations[key]++
+		} else {
+			k.durations[key] = 0
+		}
+	}
+	k.runes = src.AppendInputChars(k.runes[:0])
+}
+
+func (k *keyboard) isKeyPressed(key ebiten.Key) bool {
+	return k.durations[key] > 0
+}
+
+func (k *keyboard) isKeyJustPressed(key ebiten.Key) bool {
+	return k.durations[key] == 1
+}
+
+func (k *keyboard) keyRepeated(key ebiten.Key) bool {
+	return repeated(k.durations[key])
+}
+
+// stickThreshold is the threshold of a stick's axis value to be regarded as tilted.
+const stickThreshold = 0.5
+
+// gamepad is the state of the gamepads. The buttons and the sticks of all the gamepads are merged.
+type gamepad struct {
+	ids       []ebiten.GamepadID
+	durations [ebiten.StandardGamepadButtonMax + 1]int
+
+	// leftStickDurations is the durations of the left stick tilted to the left and the right.
+	leftStickDurations [2]int
+}
+
+func (g *gamepad) update(src InputSource) {
+	gsrc, ok := src.(GamepadInputSource)
+	if !ok {
+		*g = gamepad{
+			ids: g.ids[:0],
+		}
+		return
+	}
+	g.ids = gsrc.AppendGamepadIDs(g.ids[:0])
+
+	for b := ebiten.StandardGamepadButton(0); b <= ebiten.StandardGamepadButtonMax; b++ {
+		if slices.ContainsFunc(g.ids, func(id ebiten.GamepadID) bool {
+			return gsrc.IsStandardGamepadButtonPressed(id, b)
+		}) {
+			g.durations[b]++
+		} else {
+			g.durations[b] = 0
+		}
+	}
+
+	var x float64
+	for _, id := range g.ids {
+		if v := gsrc.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal); math.Abs(v) > math.Abs(x) {
+			x = v
+		}
+	}
+	if x <= -stickThreshold {
+		g.leftStickDurations[0]++
+	} else {
+		g.leftStickDurations[0] = 0
+	}
+	if x >= stickThreshold {
+		g.leftStickDurations[1]++
+	} else {
+		g.leftStickDurations[1] = 0
+	}
+}
+
+func (g *gamepad) isButtonJustPressed(button ebiten.StandardGamepadButton) bool {
+	return g.durations[button] == 1
+}
+
+func (g *gamepad) buttonRepeated(button ebiten.StandardGamepadButton) bool {
+	return repeated(g.durations[button])
+}
+
+// leftStickRepeated reports whether the left stick is tilted to the left (dir < 0) or the right (dir > 0),
+// in the same way as keyRepeated.
+func (g *gamepad) leftStickRepeated(dir int) bool {
+	if dir < 0 {
+		return repeated(g.leftStickDurations[0])
+	}
+	return repeated(g.leftStickDurations[1])
 }
 
 func repeated(duration int) bool {
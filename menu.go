@@ -0,0 +1,452 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"errors"
+	"image"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// menuBar is a menu bar being created.
+type menuBar struct {
+	id     widgetID
+	bounds image.Rectangle
+
+	// x is the X position of the next menu in the menu bar.
+	x int
+}
+
+// MenuBar creates a menu bar with the menus defined by the function f.
+//
+// f must add the menus by calling [Context.Menu].
+// The menu bar is laid out in a row of the current container. Call MenuBar first in a window to show the menu bar under the title bar.
+// To show a menu bar at the top of the screen, use [Context.MainMenuBar].
+//
+// A MenuBar widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) MenuBar(f func()) {
+	pc := caller()
+	idPart := c.nextIDPart(pc)
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		var err error
+		c.idScopeFromIDPart(idPart, func(id widgetID) {
+			err = c.menuBar(id, f)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
+
+// MainMenuBar creates a menu bar at the top of the screen with the menus defined by the function f.
+//
+// f must add the menus by calling [Context.Menu].
+// The menu bar is a root container as wide as the screen.
+//
+// A MainMenuBar is uniquely determined by its call location.
+func (c *Context) MainMenuBar(f func()) {
+	pc := caller()
+	idPart := c.nextIDPart(pc)
+	id := c.idStack.push(idPart)
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		opt := optionNoResize | optionNoScroll | optionNoTitle
+		cnt := c.container(id, opt)
+		width := c.screenSizeInUI().X
+		if width == 0 {
+			// The screen size is unknown, e.g. before the first Draw.
+			width = fittingWindowMeasuringSize
+		}
+		cnt.layout.Bounds = image.Rect(0, 0, width, c.defaultHeight()+2*c.style().Padding)
+		var menuBarErr error
+		if err := c.window("", cnt.layout.Bounds, opt, idPart, func(layout ContainerLayout) {
+			menuBarErr = c.menuBar(id, f)
+		}); err != nil {
+			return nil, err
+		}
+		if menuBarErr != nil {
+			return nil, menuBarErr
+		}
+		return nil, nil
+	})
+}
+
+func (c *Context) menuBar(id widgetID, f func()) error {
+	if err := c.setGridLayout(nil, nil); err != nil {
+		return err
+	}
+	bounds, err := c.layoutNext()
+	if err != nil {
+		return err
+	}
+	c.drawFrame(bounds, ColorBase)
+
+	origMenuBar := c.currentMenuBar
+	c.currentMenuBar = &menuBar{
+		id:     id,
+		bounds: bounds,
+		x:      bounds.Min.X,
+	}
+	defer func() {
+		c.currentMenuBar = origMenuBar
+	}()
+	f()
+	return nil
+}
+
+// Menu creates a menu with the label and the items defined by the function f.
+//
+// f must add the items by calling [Context.MenuItem], [Context.MenuCheckItem] or Menu.
+//
+// In a menu bar, the label is shown in the menu bar, and clicking the label opens the menu.
+// While a menu of the menu bar is open, hovering another label opens its menu instead.
+// In another menu, Menu adds an item to open the menu as a submenu by hovering the item.
+// Menu must be called in the function of [Context.MenuBar], [Context.MainMenuBar], [Context.ContextMenu] or another Menu.
+//
+// A Menu is uniquely determined by its call location.
+// Function calls made in different locations will create different menus.
+// If you want to generate different menus with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) Menu(label string, f func()) {
+	pc := caller()
+	idPart := c.nextIDPart(pc)
+	id := c.idStack.push(idPart)
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		var err error
+		switch {
+		case c.menuLevel > 0:
+			err = c.subMenu(label, id, idPart, f)
+		case c.currentMenuBar != nil:
+			err = c.menuBarMenu(label, id, idPart, f)
+		default:
+			err = errors.New("debugui: Menu must be called in the function of MenuBar, MainMenuBar, ContextMenu or Menu")
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
+
+// MenuItem creates a menu item with the label, and returns an EventHandler to handle the choice of the item.
+//
+// shortcut is the text shown at the right of the label like "Ctrl+S". shortcut can be empty.
+// MenuItem only shows the shortcut, and doesn't handle the shortcut keys.
+// Choosing the item closes all the menus.
+//
+// MenuItem must be called in the function of [Context.Menu] or [Context.ContextMenu].
+//
+// A MenuItem widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) MenuItem(label, shortcut string) EventHandler {
+	pc := caller()
+	id := c.idStack.push(c.nextIDPart(pc))
+	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		return c.menuItem(id, label, shortcut, nil)
+	})
+}
+
+// MenuCheckItem creates a menu item with the label and a check mark showing the boolean state,
+// and returns an EventHandler to handle the change of the state.
+//
+// Choosing the item toggles the state, and closes all the menus.
+// See [Context.MenuItem] for shortcut.
+//
+// MenuCheckItem must be called in the function of [Context.Menu] or [Context.ContextMenu].
+//
+// A MenuCheckItem widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) MenuCheckItem(checked *bool, label, shortcut string) EventHandler {
+	pc := caller()
+	id := c.idStack.push(c.nextIDPart(pc))
+	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		defer recordEdit(c, id, checked, *checked)
+		return c.menuItem(id, label, shortcut, checked)
+	})
+}
+
+// ContextMenu creates a context menu with the items defined by the function f for the last widget.
+//
+// The context menu is opened at the pointing position by a secondary click on the widget,
+// i.e., by right-clicking the widget, or long-pressing it on a touchscreen.
+// f must add the items by calling [Context.MenuItem], [Context.MenuCheckItem] or [Context.Menu].
+//
+// ContextMenu must be called right after the widget is created, e.g.,
+//
+//	ctx.Slider(&gravity, 0, 100, 1)
+//	ctx.ContextMenu(func() {
+//		ctx.MenuItem("Reset", "").On(func() {
+//			gravity = 0
+//		})
+//	})
+//
+// ContextMenu does nothing for a widget without user interaction like Text.
+//
+// A ContextMenu is uniquely determined by its call location.
+func (c *Context) ContextMenu(f func()) {
+	pc := caller()
+	idPart := c.nextIDPart(pc)
+	id := c.idStack.push(idPart)
+	target := c.currentID
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		if target != (widgetID{}) && c.hover == target && c.pointing.secondaryJustPressed() {
+			c.closeMenus()
+			c.openMenu(0, id)
+			c.contextMenuPosition = c.pointingPosition()
+			c.menuPressed = true
+		}
+		if !c.isMenuOpen(0, id) {
+			return nil, nil
+		}
+
+		// Place the context menu at the pointing position, and flip it to keep it inside the screen.
+		if err := c.menuPopup(0, id, idPart, "", func(size image.Point) image.Point {
+			p := c.contextMenuPosition
+			return popupPosition(p, p, size, c.screenSizeInUI())
+		}, f); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
+
+// menuBarMenu creates a label of a menu in the menu bar, and the menu if it is open.
+func (c *Context) menuBarMenu(label string, id widgetID, idPart string, f func()) error {
+	bar := c.currentMenuBar
+	w := c.textWidth(label) + 2*c.style().Padding
+	bounds := image.Rect(bar.x, bar.bounds.Min.Y, bar.x+w, bar.bounds.Max.Y)
+	bar.x += w
+
+	_ = c.widgetWithBounds(id, 0, bounds, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+		switch {
+		case (c.pointing.justPressed() && c.focus == id) || c.navActivated(id):
+			c.menuPressed = true
+			if c.isMenuOpen(0, id) {
+				c.closeMenus()
+			} else {
+				c.openMenu(0, id)
+				c.openMenuBarID = bar.id
+			}
+		case c.hover == id && len(c.openMenus) > 0 && c.openMenuBarID == bar.id:
+			// Switch the open menu of the menu bar by hovering.
+			if !c.isMenuOpen(0, id) {
+				c.openMenu(0, id)
+			}
+		}
+		return nil
+	}, func(bounds image.Rectangle) {
+		if c.isMenuOpen(0, id) {
+			c.drawFrame(bounds, ColorButtonFocus)
+		} else if c.hover == id {
+			c.drawFrame(bounds, ColorButtonHover)
+		}
+		c.drawWidgetText(label, bounds, ColorText, optionAlignCenter)
+	})
+	if visible := bounds.Intersect(c.clipRect()); !visible.Empty() {
+		c.registerFocusable(id, visible, 0)
+		c.drawFocusRing(id, visible)
+	}
+
+	if !c.isMenuOpen(0, id) {
+		return nil
+	}
+	// Place the menu below the label, or above the label if there is not enough space.
+	return c.menuPopup(0, id, idPart, menuKey(id, label), func(size image.Point) image.Point {
+		screen := c.screenSizeInUI()
+		return popupPosition(image.Pt(bounds.Min.X, bounds.Max.Y), image.Pt(screen.X, bounds.Min.Y), size, screen)
+	}, f)
+}
+
+// subMenu creates an item to open a submenu, and the submenu if it is open.
+func (c *Context) subMenu(label string, id widgetID, idPart string, f func()) error {
+	level := c.menuLevel
+	parent := c.currentRootContainer().layout.Bounds
+	var bounds image.Rectangle
+	if _, err := c.menuRow(id, label, "", nil, true, func(b image.Rectangle, activated bool) {
+		bounds = b
+		if activated || c.hover == id {
+			if activated {
+				c.menuPressed = true
+			}
+			if !c.isMenuOpen(level, id) {
+				c.openMenu(level, id)
+			}
+		}
+	}); err != nil {
+		return err
+	}
+
+	if !c.isMenuOpen(level, id) {
+		return nil
+	}
+	// Place the submenu at the right of the parent menu, or at the left if there is not enough space.
+	return c.menuPopup(level, id, idPart, menuKey(id, label), func(size image.Point) image.Point {
+		screen := c.screenSizeInUI()
+		p := image.Pt(parent.Max.X, bounds.Min.Y-c.style().Padding)
+		return popupPosition(p, image.Pt(parent.Min.X, screen.Y), size, screen)
+	}, f)
+}
+
+// menuItem creates a menu item. If checked is not nil, the item toggles the state.
+func (c *Context) menuItem(id widgetID, label, shortcut string, checked *bool) (EventHandler, error) {
+	if c.menuLevel == 0 {
+		return nil, errors.New("debugui: a menu item must be called in the function of Menu or ContextMenu")
+	}
+	level := c.menuLevel
+	return c.menuRow(id, label, shortcut, checked, false, func(bounds image.Rectangle, activated bool) {
+		// Hovering an item closes the submenus of the other items.
+		if c.hover == id && len(c.openMenus) > level {
+			c.openMenus = c.openMenus[:level]
+		}
+	})
+}
+
+// menuRow creates a row of a menu. handle is called with whether the row is chosen.
+//
+// The width of a row is the maximum width of the rows of the menu in the last frame, so that all the rows have the same width.
+func (c *Context) menuRow(id widgetID, label, shortcut string, checked *bool, submenu bool, handle func(bounds image.Rectangle, activated bool)) (EventHandler, error) {
+	padding := c.style().Padding
+	lh := c.lineHeight()
+	w := padding + lh + c.textWidth(label) + padding
+	if shortcut != "" {
+		w += lh + c.textWidth(shortcut)
+	}
+	if submenu {
+		w += lh
+	}
+	cnt := c.currentRootContainer()
+	cnt.nextMenuItemWidth = max(cnt.nextMenuItemWidth, w)
+	if err := c.setGridLayout([]int{max(w, cnt.menuItemWidth)}, nil); err != nil {
+		return nil, err
+	}
+
+	level := c.menuLevel
+	return c.widget(id, 0, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+		var e EventHandler
+		activated := (c.pointing.justPressed() && c.focus == id) || c.navActivated(id)
+		handle(bounds, activated)
+		if activated && !submenu {
+			if checked != nil {
+				*checked = !*checked
+			}
+			e = &eventHandler{}
+			c.closeMenus()
+		}
+		return e
+	}, func(bounds image.Rectangle) {
+		if c.hover == id || (submenu && c.isMenuOpen(level, id)) {
+			c.drawFrame(bounds, ColorButtonHover)
+		}
+		clr := c.style().Colors[ColorText]
+		box := image.Rect(bounds.Min.X+padding, bounds.Min.Y+(bounds.Dy()-lh)/2, bounds.Min.X+padding+lh, bounds.Min.Y+(bounds.Dy()-lh)/2+lh)
+		if checked != nil && *checked {
+			c.drawIcon(IconCheck, box, clr)
+		}
+		y := bounds.Min.Y + (bounds.Dy()-lh)/2
+		c.drawText(label, image.Pt(box.Max.X, y), clr)
+		right := bounds.Max.X - padding
+		if submenu {
+			c.drawIcon(IconRight, image.Rect(right-lh, box.Min.Y, right, box.Max.Y), clr)
+			right -= lh
+		}
+		if shortcut != "" {
+			c.drawText(shortcut, image.Pt(right-c.textWidth(shortcut), y), clr)
+		}
+		c.setDrawingWidgetKey(menuKey(id, label))
+	})
+}
+
+// menuPopup creates the root container of the open menu at the level.
+func (c *Context) menuPopup(level int, id widgetID, idPart string, key string, position func(size image.Point) image.Point, f func()) error {
+	opt := optionNoResize | optionNoScroll | optionNoTitle
+	cnt := c.container(id, opt)
+	cnt.open = true
+
+	origMenuBar, origMenuLevel := c.currentMenuBar, c.menuLevel
+	c.currentMenuBar = nil
+	c.menuLevel = level + 1
+	defer func() {
+		c.currentMenuBar = origMenuBar
+		c.menuLevel = origMenuLevel
+	}()
+	if key != "" {
+		c.pushKeyPath(key)
+		defer c.popKeyPath()
+	}
+
+	if err := c.fittingWindow(cnt, "", opt, idPart, position, func(layout ContainerLayout) {
+		f()
+	}); err != nil {
+		return err
+	}
+	cnt.menuItemWidth = cnt.nextMenuItemWidth
+	cnt.nextMenuItemWidth = 0
+	return nil
+}
+
+// menuKey returns the key of a menu or a menu item used for the ID path.
+func menuKey(id widgetID, label string) string {
+	if key, ok := keyFromID(id); ok {
+		return key
+	}
+	return label
+}
+
+// isMenuOpen reports whether the menu is open at the level.
+func (c *Context) isMenuOpen(level int, id widgetID) bool {
+	return len(c.openMenus) > level && c.openMenus[level] == id
+}
+
+// openMenu opens the menu at the level, and closes the other menus at the same or deeper levels.
+func (c *Context) openMenu(level int, id widgetID) {
+	if len(c.openMenus) < level {
+		return
+	}
+	c.openMenus = append(c.openMenus[:level], id)
+}
+
+// closeMenus closes all the menus.
+func (c *Context) closeMenus() {
+	c.openMenus = slices.Delete(c.openMenus, 0, len(c.openMenus))
+	c.openMenuBarID = widgetID{}
+}
+
+// updateMenus closes the menus that are not shown anymore or dismissed, and brings the open menus to front.
+//
+// The menus are dismissed by pressing outside the menus, Escape or the gamepad's B button.
+func (c *Context) updateMenus() {
+	for i, id := range c.openMenus {
+		if cnt, ok := c.idToContainer[id]; !ok || !cnt.used {
+			c.openMenus = c.openMenus[:i]
+			break
+		}
+	}
+	if len(c.openMenus) == 0 {
+		c.closeMenus()
+		return
+	}
+
+	if (c.pointing.justPressed() || c.pointing.secondaryJustPressed()) && !c.menuPressed {
+		cnt := c.hoveringRootContainer()
+		if cnt == nil || !slices.ContainsFunc(c.openMenus, func(id widgetID) bool {
+			return c.idToContainer[id] == cnt
+		}) {
+			c.closeMenus()
+			return
+		}
+	}
+	if c.keyboard.isKeyJustPressed(ebiten.KeyEscape) || c.navCanceled() {
+		c.closeMenus()
+		return
+	}
+
+	for _, id := range c.openMenus {
+		c.bringToFront(c.idToContainer[id])
+	}
+}
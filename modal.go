@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"strings"
+)
+
+// confirmMaxWidth is the maximum width of a message of a confirmation dialog. A longer message is wrapped.
+const confirmMaxWidth = 320
+
+// ModalID is the ID of a modal window.
+type ModalID widgetID
+
+// OpenModal opens a modal window.
+//
+// While a modal window is open, the screen behind it is dimmed, and the windows behind it don't get any input.
+func (c *Context) OpenModal(modalID ModalID) {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		cnt := c.container(widgetID(modalID), 0)
+		// Reset the size to measure the content again.
+		cnt.layout.Bounds = image.Rectangle{}
+		cnt.open = true
+		// Capture the input from the current frame, even before the modal window is created for the first time.
+		cnt.windowOptions |= optionModal
+		c.bringToFront(cnt)
+		// Release the widgets behind the modal window.
+		c.setFocus(widgetID{})
+		c.navFocus = widgetID{}
+		return nil, nil
+	})
+}
+
+// CloseModal closes a modal window.
+func (c *Context) CloseModal(modalID ModalID) {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		cnt := c.container(widgetID(modalID), 0)
+		cnt.open = false
+		return nil, nil
+	})
+}
+
+// Modal creates a modal window with the title and the content defined by the provided function,
+// and returns the ModalID of the modal window.
+//
+// By default, the modal window is hidden.
+// To show the modal window, call OpenModal with the ModalID returned by this function.
+// The modal window is shown at the center of the screen and resized to fit its content.
+// Unlike a popup, a modal window is not closed by clicking elsewhere. Call CloseModal to close it.
+//
+// A Modal is uniquely determined by its call location.
+func (c *Context) Modal(title string, f func(layout ContainerLayout, modalID ModalID)) ModalID {
+	pc := caller()
+	idPart := c.nextIDPart(pc)
+	id := c.idStack.push(idPart)
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		if err := c.modal(title, idPart, id, func(layout ContainerLayout) {
+			f(layout, ModalID(id))
+		}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	return ModalID(id)
+}
+
+// Confirm creates a modal window to show the message with the buttons, and returns the ModalID of the modal window
+// and the index of the button chosen in the current frame.
+// If no button is chosen, the index is -1.
+//
+// Choosing a button closes the modal window. If buttons is empty, an "OK" button is shown.
+// To show the modal window, call OpenModal with the ModalID returned by this function, e.g.,
+//
+//	modalID, chosen := ctx.Confirm("Delete Save File?", "The save file cannot be restored.", "Delete", "Cancel")
+//	if chosen == 0 {
+//		deleteSaveFile()
+//	}
+//	ctx.Button("Delete Save File").On(func() {
+//		ctx.OpenModal(modalID)
+//	})
+//
+// A Confirm is uniquely determined by its call location.
+func (c *Context) Confirm(title, message string, buttons ...string) (ModalID, int) {
+	pc := caller()
+	idPart := c.nextIDPart(pc)
+	id := c.idStack.push(idPart)
+	if len(buttons) == 0 {
+		buttons = []string{"OK"}
+	}
+	chosen := -1
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		if err := c.modal(title, idPart, id, func(layout ContainerLayout) {
+			chosen = c.confirm(message, buttons)
+		}); err != nil {
+			return nil, err
+		}
+		if chosen >= 0 {
+			c.idToContainer[id].open = false
+		}
+		return nil, nil
+	})
+	return ModalID(id), chosen
+}
+
+func (c *Context) confirm(message string, buttons []string) int {
+	var w int
+	for _, line := range strings.Split(message, "\n") {
+		w = max(w, c.textWidth(line))
+	}
+	c.SetGridLayout([]int{min(w+c.style().Padding, confirmMaxWidth)}, nil)
+	c.Text(message)
+
+	widths := make([]int, len(buttons))
+	for i, label := range buttons {
+		widths[i] = max(c.style().DefaultWidth, c.textWidth(label)) + 2*c.style().Padding
+	}
+	c.SetGridLayout(widths, nil)
+	chosen := -1
+	for i, label := range buttons {
+		c.wrapEventHandlerAndError(func() (EventHandler, error) {
+			return c.button(label, optionAlignCenter, c.idStack.push(idPartFromInt(i)))
+		}).On(func() {
+			chosen = i
+		})
+	}
+	return chosen
+}
+
+func (c *Context) modal(title string, idPart string, id widgetID, f func(layout ContainerLayout)) error {
+	opt := optionModal | optionNoResize | optionNoScroll | optionNoClose | optionClosed
+	cnt := c.container(id, opt)
+	if cnt == nil || !cnt.open {
+		return nil
+	}
+
+	// Center the modal window.
+	// A modal window just opened is measured first, so the press that opened it doesn't reach its content.
+	return c.fittingWindow(cnt, title, opt, idPart, func(size image.Point) image.Point {
+		var p image.Point
+		if screen := c.screenSizeInUI(); screen.X > 0 && screen.Y > 0 {
+			p = screen.Sub(size).Div(2)
+		}
+		p.X = max(p.X, 0)
+		p.Y = max(p.Y, 0)
+		return p
+	}, f)
+}
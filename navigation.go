@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// focusable is a widget that can get the navigation focus.
+type focusable struct {
+	id     widgetID
+	bounds image.Rectangle
+}
+
+// registerFocusable registers the widget as a target of the navigation focus in the current frame.
+//
+// Only the widgets in the frontmost root container can get the navigation focus.
+func (c *Context) registerFocusable(id widgetID, bounds image.Rectangle, opt option) {
+	if id == (widgetID{}) {
+		return
+	}
+	if opt&(optionNoInteract|optionNoNavigation) != 0 {
+		return
+	}
+	if c.currentRootContainer() != c.frontRootContainer() {
+		return
+	}
+	c.focusables = append(c.focusables, focusable{
+		id:     id,
+		bounds: bounds,
+	})
+}
+
+// navFocused reports whether the widget has the visible navigation focus.
+//
+// Widgets handle the keys for the navigation only when navFocused returns true.
+func (c *Context) navFocused(id widgetID) bool {
+	return c.navFocusVisible && c.navFocus == id
+}
+
+// navActivated reports whether the widget with the navigation focus is activated by Space, Enter or the gamepad's A button.
+func (c *Context) navActivated(id widgetID) bool {
+	if !c.navFocused(id) || c.focus != (widgetID{}) {
+		return false
+	}
+	return c.keyboard.isKeyJustPressed(ebiten.KeySpace) || c.navConfirmed()
+}
+
+// navConfirmed reports whether Enter or the gamepad's A button is just pressed.
+func (c *Context) navConfirmed() bool {
+	return c.keyboard.isKeyJustPressed(ebiten.KeyEnter) || c.gamepad.isButtonJustPressed(ebiten.StandardGamepadButtonRightBottom)
+}
+
+// navCanceled reports whether the gamepad's B button is just pressed.
+func (c *Context) navCanceled() bool {
+	return c.gamepad.isButtonJustPressed(ebiten.StandardGamepadButtonRightRight)
+}
+
+// navAdjustment returns -1 or 1 when the widget with the navigation focus should decrease or increase its value
+// by the left and right arrow keys or the gamepad's left stick. Otherwise, navAdjustment returns 0.
+func (c *Context) navAdjustment() int {
+	var d int
+	if c.keyboard.keyRepeated(ebiten.KeyLeft) || c.gamepad.leftStickRepeated(-1) {
+		d--
+	}
+	if c.keyboard.keyRepeated(ebiten.KeyRight) || c.gamepad.leftStickRepeated(1) {
+		d++
+	}
+	return d
+}
+
+// updateNavigation moves the navigation focus by Tab, Shift+Tab and the gamepad's D-pad,
+// and cycles the windows by the gamepad's shoulder buttons.
+func (c *Context) updateNavigation() {
+	// Clicking a widget moves the navigation focus to it, but hides the focus ring.
+	if c.pointing.justPressed() {
+		c.navFocus = c.focus
+		c.navFocusVisible = false
+		return
+	}
+
+	if c.keyboard.isKeyJustPressed(ebiten.KeyEscape) && c.focus == (widgetID{}) {
+		c.navFocusVisible = false
+	}
+
+	idx := slices.IndexFunc(c.focusables, func(f focusable) bool {
+		return f.id == c.navFocus
+	})
+	if idx < 0 {
+		// The widget with the navigation focus disappeared, e.g. by closing a popup or bringing another window to front.
+		// Keep the focus visible in the frontmost window.
+		if c.navFocusVisible && len(c.focusables) > 0 {
+			idx = 0
+			c.navFocus = c.focusables[0].id
+		} else {
+			c.navFocus = widgetID{}
+			c.navFocusVisible = false
+		}
+	}
+
+	// Start the navigation only when the UI is already used, so as not to take the keyboard and the gamepad from the game.
+	if !c.navFocusVisible && c.focus == (widgetID{}) && c.hoveringRootContainer() == nil && c.modalRootContainer() == nil {
+		return
+	}
+
+	switch {
+	case c.gamepad.buttonRepeated(ebiten.StandardGamepadButtonFrontTopRight):
+		c.cycleWindows(true)
+		return
+	case c.gamepad.buttonRepeated(ebiten.StandardGamepadButtonFrontTopLeft):
+		c.cycleWindows(false)
+		return
+	}
+
+	if len(c.focusables) == 0 {
+		return
+	}
+
+	if c.keyboard.keyRepeated(ebiten.KeyTab) {
+		if c.keyboard.isKeyPressed(ebiten.KeyShift) {
+			if idx <= 0 {
+				idx = len(c.focusables)
+			}
+			idx--
+		} else {
+			idx = (idx + 1) % len(c.focusables)
+		}
+		c.navFocus = c.focusables[idx].id
+		c.navFocusVisible = true
+		return
+	}
+
+	var dir image.Point
+	switch {
+	case c.gamepad.buttonRepeated(ebiten.StandardGamepadButtonLeftTop):
+		dir = image.Pt(0, -1)
+	case c.gamepad.buttonRepeated(ebiten.StandardGamepadButtonLeftBottom):
+		dir = image.Pt(0, 1)
+	case c.gamepad.buttonRepeated(ebiten.StandardGamepadButtonLeftLeft):
+		dir = image.Pt(-1, 0)
+	case c.gamepad.buttonRepeated(ebiten.StandardGamepadButtonLeftRight):
+		dir = image.Pt(1, 0)
+	default:
+		return
+	}
+	if idx < 0 {
+		idx = 0
+	} else if next := c.nearestFocusable(c.focusables[idx].bounds, dir); next >= 0 {
+		idx = next
+	}
+	c.navFocus = c.focusables[idx].id
+	c.navFocusVisible = true
+}
+
+// nearestFocusable returns the index of the nearest focusable widget from bounds in the direction dir.
+// If there is no such widget, nearestFocusable returns -1.
+func (c *Context) nearestFocusable(bounds image.Rectangle, dir image.Point) int {
+	from := bounds.Min.Add(bounds.Max)
+	nearest := -1
+	var nearestDist int
+	for i, f := range c.focusables {
+		// Compare the doubled centers to avoid rounding errors.
+		d := f.bounds.Min.Add(f.bounds.Max).Sub(from)
+		// main is the distance in the direction, and cross is the distance perpendicular to the direction.
+		main := d.X*dir.X + d.Y*dir.Y
+		if main <= 0 {
+			continue
+		}
+		cross := d.X*dir.Y - d.Y*dir.X
+		if cross < 0 {
+			cross = -cross
+		}
+		// Prefer the widgets aligned with the current widget.
+		dist := main + 2*cross
+		if nearest < 0 || dist < nearestDist {
+			nearest = i
+			nearestDist = dist
+		}
+	}
+	return nearest
+}
+
+// cycleWindows brings the backmost window to front if forward is true.
+// Otherwise, cycleWindows sends the frontmost window to back.
+//
+// Popups and dropdowns are not cycled. Windows are not cycled while a modal window is open.
+func (c *Context) cycleWindows(forward bool) {
+	if c.modalRootContainer() != nil {
+		return
+	}
+	var windows []*container
+	for _, cnt := range c.rootContainers {
+		if !cnt.open || cnt.windowOptions&(optionPopup|optionNoTitle) != 0 {
+			continue
+		}
+		windows = append(windows, cnt)
+	}
+	if len(windows) < 2 {
+		return
+	}
+	if forward {
+		c.bringToFront(windows[0])
+	} else {
+		c.sendToBack(windows[len(windows)-1])
+	}
+	// The navigation focus moves to the new frontmost window in the next frame.
+	c.navFocus = widgetID{}
+	c.navFocusVisible = true
+}
+
+// drawFocusRing draws the focus ring around the widget if the widget has the visible navigation focus.
+func (c *Context) drawFocusRing(id widgetID, bounds image.Rectangle) {
+	if !c.navFocused(id) {
+		return
+	}
+	c.drawBox(bounds.Inset(-2), c.style().Colors[ColorFocusRing])
+}
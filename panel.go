@@ -3,11 +3,13 @@
 
 package debugui
 
+import "image"
+
 // Panel creates a new panel with the contents defined by the function f.
 // Panel can have scroll bars, and the contents of the panel can be scrolled.
 func (c *Context) Panel(f func(layout ContainerLayout)) {
 	pc := caller()
-	idPart := idPartFromCaller(pc)
+	idPart := c.nextIDPart(pc)
 	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
 		if err := c.panel(0, idPart, f); err != nil {
 			return nil, err
@@ -24,14 +26,18 @@ func (c *Context) panel(opt option, idPart string, f func(layout ContainerLayout
 }
 
 func (c *Context) doPanel(opt option, id widgetID, f func(layout ContainerLayout)) (err error) {
-	cnt := c.container(id, opt)
-	l, err := c.layoutNext()
+	bounds, err := c.layoutNext()
 	if err != nil {
 		return err
 	}
-	cnt.layout.Bounds = l
+	return c.doPanelWithBounds(opt, id, bounds, f)
+}
+
+func (c *Context) doPanelWithBounds(opt option, id widgetID, bounds image.Rectangle, f func(layout ContainerLayout)) (err error) {
+	cnt := c.container(id, opt)
+	cnt.layout.Bounds = bounds
 	if (^opt & optionNoFrame) != 0 {
-		c.drawFrame(cnt.layout.Bounds, colorPanelBG)
+		c.drawFrame(cnt.layout.Bounds, ColorPanelBG)
 	}
 
 	c.pushContainer(cnt, false)
@@ -49,6 +55,12 @@ func (c *Context) doPanel(opt option, id widgetID, f func(layout ContainerLayout
 	c.pushClipRect(cnt.layout.BodyBounds)
 	defer c.popClipRect()
 
+	// The key is not pushed for a panel that is the body of a widget like a text area, as the key is already at the end of the widget's ID path.
+	if key, ok := keyFromID(id); ok && (^opt&optionNoKeyPath) != 0 {
+		c.pushKeyPath(key)
+		defer c.popKeyPath()
+	}
+
 	f(c.currentContainer().layout)
 	return nil
 }
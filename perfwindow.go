@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"cmp"
+	"fmt"
+	"image"
+	"math"
+	"runtime/metrics"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// perfHistorySize is the number of the values kept in a history of the performance window.
+	perfHistorySize = 120
+
+	// perfMetricsInterval is the interval to read the runtime metrics.
+	perfMetricsInterval = 500 * time.Millisecond
+)
+
+const (
+	perfMetricHeapObjects = "/memory/classes/heap/objects:bytes"
+	perfMetricHeapGoal    = "/gc/heap/goal:bytes"
+	perfMetricTotalMemory = "/memory/classes/total:bytes"
+	perfMetricGoroutines  = "/sched/goroutines:goroutines"
+	perfMetricGCCycles    = "/gc/cycles/total:gc-cycles"
+	perfMetricGCPauses    = "/sched/pauses/total/gc:seconds"
+)
+
+// perfHistory is a ring buffer of values shown in the performance window.
+type perfHistory struct {
+	values [perfHistorySize]float64
+	start  int
+	count  int
+}
+
+func (h *perfHistory) push(v float64) {
+	if h.count < len(h.values) {
+		h.values[h.count] = v
+		h.count++
+		return
+	}
+	h.values[h.start] = v
+	h.start = (h.start + 1) % len(h.values)
+}
+
+func (h *perfHistory) series(label string) PlotSeries {
+	return PlotSeries{
+		Label:  label,
+		Values: h.values[:h.count],
+		Start:  h.start,
+	}
+}
+
+// perfContainerStat is the cost of a root container in the last frame.
+type perfContainerStat struct {
+	name     string
+	commands int
+}
+
+// perfStats is the statistics shown in the performance window.
+type perfStats struct {
+	lastFrame  time.Time
+	frameTimes perfHistory
+
+	lastMetrics time.Time
+	samples     []metrics.Sample
+	heapHistory perfHistory
+
+	// gcPauseCounts is the counts of the GC pause histogram at the last read, to calculate the recent pauses.
+	gcPauseCounts []uint64
+	maxGCPause    float64
+
+	containers []perfContainerStat
+}
+
+// PerfWindow creates a window to show the performance of the game.
+//
+// The window shows the actual TPS and FPS, the history of the frame times, the memory statistics of the Go runtime,
+// and the cost of the debug UI itself, i.e. the number of the drawing commands per root container and the number of the live containers.
+// The frame time is the interval between the calls of PerfWindow, so PerfWindow should be called at every Update.
+//
+// A PerfWindow is uniquely determined by its call location.
+func (c *Context) PerfWindow() {
+	pc := caller()
+	idPart := c.nextIDPart(pc)
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		if c.perf == nil {
+			c.perf = &perfStats{}
+		}
+		c.perf.update()
+		if err := c.window("Performance", image.Rect(60, 60, 360, 560), 0, idPart, func(layout ContainerLayout) {
+			c.perfWindow()
+		}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
+
+func (p *perfStats) update() {
+	now := time.Now()
+	if !p.lastFrame.IsZero() {
+		p.frameTimes.push(float64(now.Sub(p.lastFrame)) / float64(time.Millisecond))
+	}
+	p.lastFrame = now
+
+	if !p.lastMetrics.IsZero() && now.Sub(p.lastMetrics) < perfMetricsInterval {
+		return
+	}
+	p.lastMetrics = now
+
+	if p.samples == nil {
+		for _, name := range []string{
+			perfMetricHeapObjects,
+			perfMetricHeapGoal,
+			perfMetricTotalMemory,
+			perfMetricGoroutines,
+			perfMetricGCCycles,
+			perfMetricGCPauses,
+		} {
+			p.samples = append(p.samples, metrics.Sample{Name: name})
+		}
+	}
+	metrics.Read(p.samples)
+	p.heapHistory.push(float64(p.metricUint64(perfMetricHeapObjects)) / (1 << 20))
+
+	// Find the longest GC pause since the last read.
+	if s := p.sample(perfMetricGCPauses); s.Value.Kind() == metrics.KindFloat64Histogram {
+		h := s.Value.Float64Histogram()
+		p.maxGCPause = 0
+		for i := len(h.Counts) - 1; i >= 0; i-- {
+			var last uint64
+			if i < len(p.gcPauseCounts) {
+				last = p.gcPauseCounts[i]
+			}
+			if h.Counts[i] > last {
+				// Buckets[i+1] is the upper bound of the i-th bucket. Use the lower bound for the unbounded bucket.
+				p.maxGCPause = h.Buckets[i+1]
+				if math.IsInf(p.maxGCPause, 1) {
+					p.maxGCPause = h.Buckets[i]
+				}
+				break
+			}
+		}
+		p.gcPauseCounts = append(p.gcPauseCounts[:0], h.Counts...)
+	}
+}
+
+func (p *perfStats) sample(name string) *metrics.Sample {
+	for i := range p.samples {
+		if p.samples[i].Name == name {
+			return &p.samples[i]
+		}
+	}
+	return nil
+}
+
+// metricUint64 returns the value of the metric, or 0 if the metric is not supported.
+func (p *perfStats) metricUint64(name string) uint64 {
+	s := p.sample(name)
+	if s == nil || s.Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return s.Value.Uint64()
+}
+
+// recordContainers records the number of the drawing commands of the root containers in the last frame.
+func (p *perfStats) recordContainers(rootContainers []*container) {
+	p.containers = p.containers[:0]
+	for _, cnt := range rootContainers {
+		if !cnt.open {
+			continue
+		}
+		p.containers = append(p.containers, perfContainerStat{
+			name:     cmp.Or(cnt.key, "(unnamed)"),
+			commands: len(cnt.commandList),
+		})
+	}
+}
+
+func (c *Context) perfWindow() {
+	p := c.perf
+
+	c.Header("Frame", true, func() {
+		c.SetGridLayout([]int{-1, -1}, nil)
+		c.Text(fmt.Sprintf("TPS: %0.2f", ebiten.ActualTPS()))
+		c.Text(fmt.Sprintf("FPS: %0.2f", ebiten.ActualFPS()))
+		c.SetGridLayout([]int{-1}, []int{60})
+		c.Plot([]PlotSeries{p.frameTimes.series("ms")}, nil)
+	})
+
+	c.Header("Memory", true, func() {
+		c.SetGridLayout([]int{-1, -1}, nil)
+		for _, m := range []struct {
+			name  string
+			value string
+		}{
+			{"Heap Objects", formatBytes(p.metricUint64(perfMetricHeapObjects))},
+			{"Heap Goal", formatBytes(p.metricUint64(perfMetricHeapGoal))},
+			{"Total Memory", formatBytes(p.metricUint64(perfMetricTotalMemory))},
+			{"Goroutines", fmt.Sprintf("%d", p.metricUint64(perfMetricGoroutines))},
+			{"GC Cycles", fmt.Sprintf("%d", p.metricUint64(perfMetricGCCycles))},
+			{"GC Pause (Recent Max)", fmt.Sprintf("%0.3f ms", p.maxGCPause*1000)},
+		} {
+			c.Text(m.name + ":")
+			c.Text(m.value)
+		}
+		c.SetGridLayout([]int{-1}, []int{60})
+		c.Plot([]PlotSeries{p.heapHistory.series("MiB")}, nil)
+	})
+
+	c.Header("Debug UI", true, func() {
+		c.SetGridLayout([]int{-1, -1}, nil)
+		c.Text("Live Containers:")
+		c.Text(fmt.Sprintf("%d", len(c.idToContainer)))
+		c.Text("Root Container")
+		c.Text("Commands")
+		for _, s := range p.containers {
+			c.Text(s.name)
+			c.Text(fmt.Sprintf("%d", s.commands))
+		}
+	})
+}
+
+// formatBytes formats the number of bytes in a human-readable form like "1.5 MiB".
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	v := float64(n)
+	for _, suffix := range []string{"KiB", "MiB", "GiB"} {
+		v /= unit
+		if v < unit || suffix == "GiB" {
+			return fmt.Sprintf("%0.1f %s", v, suffix)
+		}
+	}
+	return ""
+}
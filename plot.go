@@ -0,0 +1,280 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// PlotStyle represents how the values of a plot are drawn.
+type PlotStyle int
+
+const (
+	// PlotStyleLine draws the values as lines.
+	PlotStyleLine PlotStyle = iota
+
+	// PlotStyleBar draws the values as bars.
+	PlotStyleBar
+)
+
+// PlotSeries is a series of values drawn by [Context.Plot].
+type PlotSeries struct {
+	// Label is the label of the series shown with the hovered value.
+	Label string
+
+	// Values is the values of the series.
+	//
+	// Values is treated as a ring buffer starting at Start.
+	// The values are drawn from the left in the order of Values[Start:] and Values[:Start].
+	Values []float64
+
+	// Start is the index of the oldest value in Values.
+	// Start out of the range of Values wraps around, e.g., -1 is the last index.
+	Start int
+
+	// Color is the color of the series.
+	// If Color is nil, a color is chosen by the index of the series.
+	Color color.Color
+}
+
+// value returns the i-th oldest value in the series.
+func (p *PlotSeries) value(i int) float64 {
+	n := len(p.Values)
+	start := (p.Start%n + n) % n
+	return p.Values[(start+i)%n]
+}
+
+// PlotOptions represents options for [Context.Plot].
+type PlotOptions struct {
+	// Style is the style of the plot.
+	Style PlotStyle
+
+	// Min and Max are the range of the Y axis. Values out of the range are clamped.
+	//
+	// If Min and Max are equal, the range is determined by the values automatically.
+	Min float64
+	Max float64
+}
+
+// plotColors is the colors of the series whose colors are not specified.
+var plotColors = []color.RGBA{
+	{80, 160, 240, 255},
+	{240, 140, 60, 255},
+	{90, 200, 110, 255},
+	{230, 80, 90, 255},
+	{170, 120, 230, 255},
+}
+
+// plotGridCount is the number of the divisions of the Y axis by the grid lines.
+const plotGridCount = 4
+
+// Plot creates a plot widget to draw series of values.
+//
+// The height of the plot is determined by the grid layout. See [Context.SetGridLayout].
+// Hovering the plot shows the values at the hovered position in a tooltip.
+//
+// options can be nil. If options is nil, the values are drawn as lines and the Y axis is scaled automatically.
+//
+// A Plot widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) Plot(series []PlotSeries, options *PlotOptions) {
+	pc := caller()
+	id := c.idStack.push(c.nextIDPart(pc))
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		if err := c.plot(series, options, id); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
+
+func (c *Context) plot(series []PlotSeries, options *PlotOptions, id widgetID) error {
+	if options == nil {
+		options = &PlotOptions{}
+	}
+	if options.Min > options.Max {
+		return fmt.Errorf("debugui: plot min (%f) must be less than or equal to max (%f)", options.Min, options.Max)
+	}
+
+	var count int
+	for _, s := range series {
+		count = max(count, len(s.Values))
+	}
+	low, high := options.Min, options.Max
+	if low == high {
+		low, high = plotRange(series)
+	}
+
+	_, err := c.widget(id, optionNoNavigation, nil, nil, func(bounds image.Rectangle) {
+		c.drawWidgetFrame(id, bounds, ColorBase, 0)
+		c.pushClipRect(bounds)
+		defer c.popClipRect()
+
+		area := bounds.Inset(c.style().Padding)
+		if area.Empty() {
+			return
+		}
+		c.drawPlotGrid(area)
+		if count > 0 {
+			c.drawPlotSeries(area, series, options.Style, low, high, count)
+		}
+		// Draw the labels over the values.
+		c.drawPlotLabels(area, low, high)
+		if c.hover == id && count > 0 {
+			c.drawPlotHover(area, series, options.Style, count)
+		}
+	})
+	return err
+}
+
+// drawPlotSeries draws the values of the series.
+func (c *Context) drawPlotSeries(area image.Rectangle, series []PlotSeries, style PlotStyle, low, high float64, count int) {
+	// y returns the Y position of the value v.
+	y := func(v float64) int {
+		if math.IsNaN(v) {
+			v = low
+		}
+		v = clamp(v, low, high)
+		return area.Max.Y - 1 - int(math.Round((v-low)/(high-low)*float64(area.Dy()-1)))
+	}
+
+	for i := range series {
+		s := &series[i]
+		if len(s.Values) == 0 {
+			continue
+		}
+		clr := s.Color
+		if clr == nil {
+			clr = plotColors[i%len(plotColors)]
+		}
+		switch style {
+		case PlotStyleLine:
+			prev := image.Pt(plotLineX(area, 0, count), y(s.value(0)))
+			for j := 1; j < len(s.Values); j++ {
+				p := image.Pt(plotLineX(area, j, count), y(s.value(j)))
+				c.drawLine(prev, p, clr)
+				prev = p
+			}
+			if len(s.Values) == 1 {
+				c.drawRect(image.Rectangle{Min: prev, Max: prev.Add(image.Pt(1, 1))}, clr)
+			}
+		case PlotStyleBar:
+			base := y(0)
+			for j := range len(s.Values) {
+				x0, x1 := plotBarX(area, j, count)
+				// Bars of the series are placed side by side.
+				w := x1 - x0
+				x0, x1 = x0+w*i/len(series), x0+w*(i+1)/len(series)
+				if x1-x0 > 2 {
+					x1--
+				}
+				v := y(s.value(j))
+				c.drawRect(image.Rect(x0, min(v, base), max(x1, x0+1), max(v, base)+1), clr)
+			}
+		}
+	}
+}
+
+// plotRange returns the range of the values in the series.
+func plotRange(series []PlotSeries) (low, high float64) {
+	low, high = math.Inf(1), math.Inf(-1)
+	for _, s := range series {
+		for _, v := range s.Values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			low = min(low, v)
+			high = max(high, v)
+		}
+	}
+	if low > high {
+		return 0, 1
+	}
+	if low == high {
+		return low - 1, high + 1
+	}
+	return low, high
+}
+
+// plotLineX returns the X position of the i-th value of count values drawn as a line.
+func plotLineX(area image.Rectangle, i, count int) int {
+	if count <= 1 {
+		return area.Min.X
+	}
+	return area.Min.X + i*(area.Dx()-1)/(count-1)
+}
+
+// plotBarX returns the X range of the i-th value of count values drawn as a bar.
+func plotBarX(area image.Rectangle, i, count int) (x0, x1 int) {
+	return area.Min.X + i*area.Dx()/count, area.Min.X + (i+1)*area.Dx()/count
+}
+
+// plotGridY returns the Y position of the i-th horizontal grid line from the bottom.
+func plotGridY(area image.Rectangle, i int) int {
+	return area.Max.Y - 1 - i*(area.Dy()-1)/plotGridCount
+}
+
+// drawPlotGrid draws the horizontal grid lines.
+func (c *Context) drawPlotGrid(area image.Rectangle) {
+	for i := range plotGridCount + 1 {
+		y := plotGridY(area, i)
+		c.drawLine(image.Pt(area.Min.X, y), image.Pt(area.Max.X-1, y), c.style().Colors[ColorPlotGrid])
+	}
+}
+
+// drawPlotLabels draws the labels of the Y axis at the top, the middle and the bottom, inside the area.
+func (c *Context) drawPlotLabels(area image.Rectangle, low, high float64) {
+	for i := 0; i <= plotGridCount; i += plotGridCount / 2 {
+		v := low + (high-low)*float64(i)/plotGridCount
+		y := clamp(plotGridY(area, i)-c.lineHeight()/2, area.Min.Y, area.Max.Y-c.lineHeight())
+		c.drawText(fmt.Sprintf(realFmt, v), image.Pt(area.Min.X+1, y), c.style().Colors[ColorText])
+	}
+}
+
+// drawPlotHover draws the marker at the hovered position in the plot, and shows the values there.
+func (c *Context) drawPlotHover(area image.Rectangle, series []PlotSeries, style PlotStyle, count int) {
+	pos := c.pointingPosition()
+	if !pos.In(area) {
+		return
+	}
+
+	var idx, x int
+	switch style {
+	case PlotStyleLine:
+		if count > 1 {
+			idx = int(math.Round(float64(pos.X-area.Min.X) * float64(count-1) / float64(area.Dx()-1)))
+		}
+		idx = clamp(idx, 0, count-1)
+		x = plotLineX(area, idx, count)
+	case PlotStyleBar:
+		idx = clamp((pos.X-area.Min.X)*count/area.Dx(), 0, count-1)
+		x0, x1 := plotBarX(area, idx, count)
+		x = (x0 + x1) / 2
+	}
+	c.drawLine(image.Pt(x, area.Min.Y), image.Pt(x, area.Max.Y-1), c.style().Colors[ColorText])
+
+	var lines []string
+	for _, s := range series {
+		if idx >= len(s.Values) {
+			continue
+		}
+		v := fmt.Sprintf(realFmt, s.value(idx))
+		if s.Label != "" {
+			v = s.Label + ": " + v
+		}
+		lines = append(lines, v)
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	// Show the values in a tooltip so that they are not clipped by the plot.
+	c.tooltipID = c.hover
+	c.tooltipContent = c.textTooltipContent(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"slices"
+)
+
+// RadioButton creates a radio button with the label to select the value.
+//
+// The radio button is checked when *selected is value, and clicking it sets value to *selected.
+// Radio buttons sharing the same selected work as a group.
+// Returns an EventHandler that triggers when the selection changes.
+//
+// A RadioButton widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) RadioButton(selected *int, value int, label string) EventHandler {
+	pc := caller()
+	id := c.idStack.push(c.nextIDPart(pc))
+	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		return c.radioButton(selected, value, label, id)
+	})
+}
+
+// RadioGroup creates radio buttons for the options, and returns an EventHandler that triggers when the selection changes.
+// selectedIndex is a pointer to the index of the selected option.
+//
+// The radio buttons are laid out in a row if horizontal is true, or in a column otherwise.
+// RadioGroup changes the grid layout of the current container. See [Context.SetGridLayout].
+//
+// A RadioGroup widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) RadioGroup(selectedIndex *int, options []string, horizontal bool) EventHandler {
+	pc := caller()
+	idPart := c.nextIDPart(pc)
+	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		var e EventHandler
+		var err error
+		c.idScopeFromIDPart(idPart, func(widgetID) {
+			e, err = c.radioGroup(selectedIndex, options, horizontal)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	})
+}
+
+func (c *Context) radioGroup(selectedIndex *int, options []string, horizontal bool) (EventHandler, error) {
+	widths := []int{-1}
+	if horizontal {
+		widths = slices.Repeat(widths, max(len(options), 1))
+	}
+	if err := c.setGridLayout(widths, nil); err != nil {
+		return nil, err
+	}
+
+	var e EventHandler
+	for i, option := range options {
+		e2, err := c.radioButton(selectedIndex, i, option, c.idStack.push(idPartFromInt(i)))
+		if err != nil {
+			return nil, err
+		}
+		if e2 != nil {
+			e = e2
+		}
+	}
+	return e, nil
+}
+
+func (c *Context) radioButton(selected *int, value int, label string, id widgetID) (EventHandler, error) {
+	defer recordEdit(c, id, selected, *selected)
+	return c.widget(id, 0, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+		if ((c.pointing.justPressed() && c.focus == id) || c.navActivated(id)) && *selected != value {
+			*selected = value
+			return &eventHandler{}
+		}
+		return nil
+	}, func(bounds image.Rectangle) {
+		c.drawCheckWidget(id, bounds, label, IconRadio, *selected == value)
+	})
+}
@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"iter"
+	"math"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// Renderer renders the commands generated by the debug UI.
+type Renderer interface {
+	// Render renders the commands in order.
+	//
+	// The positions of the commands are in the UI coordinate.
+	// scale is the UI scale to convert the positions into the screen coordinate.
+	Render(commands iter.Seq[Command], scale float64)
+}
+
+// Render renders the debug UI with the renderer r.
+//
+// Draw is equivalent to Render with an EbitenRenderer, except that Draw also records the screen size.
+func (d *DebugUI) Render(r Renderer) {
+	if d.ctx.err != nil {
+		return
+	}
+	r.Render(d.ctx.commands(), d.ctx.Scale())
+}
+
+// EbitenRenderer is a Renderer that renders commands onto an Ebitengine image.
+type EbitenRenderer struct {
+	// Target is the destination image.
+	Target *ebiten.Image
+}
+
+// Render implements Renderer.
+func (e *EbitenRenderer) Render(commands iter.Seq[Command], scale float64) {
+	target := e.Target
+	for cmd := range commands {
+		switch cmd := cmd.(type) {
+		case *RectCommand:
+			r := scaleRect(cmd.Rect, scale)
+			vector.DrawFilledRect(
+				target,
+				float32(r.Min.X),
+				float32(r.Min.Y),
+				float32(r.Dx()),
+				float32(r.Dy()),
+				cmd.Color,
+				false,
+			)
+		case *LineCommand:
+			// Draw the line through the centers of the pixels.
+			vector.StrokeLine(
+				target,
+				float32((float64(cmd.From.X)+0.5)*scale),
+				float32((float64(cmd.From.Y)+0.5)*scale),
+				float32((float64(cmd.To.X)+0.5)*scale),
+				float32((float64(cmd.To.Y)+0.5)*scale),
+				float32(scale),
+				cmd.Color,
+				true,
+			)
+		case *TextCommand:
+			pos := scalePoint(cmd.Position, scale)
+			op := &text.DrawOptions{}
+			face := textCommandFace(cmd)
+			if f, ok := face.(*text.GoTextFace); ok {
+				// Render the text with a scaled font face instead of scaling the rendering result, so that the text is crisp.
+				scaled := *f
+				scaled.Size *= scale
+				face = &scaled
+			} else {
+				// A face other than GoTextFace cannot be rendered at the scaled size, as a GoXFace is a bitmap
+				// and the faces in wrappers like MultiFace and LimitedFace are not accessible.
+				// Scale the rendering result, and smooth it at a non-integer scale to avoid uneven glyphs.
+				op.GeoM.Scale(scale, scale)
+				if scale != math.Trunc(scale) {
+					op.Filter = ebiten.FilterLinear
+				}
+			}
+			op.GeoM.Translate(float64(pos.X), float64(pos.Y))
+			op.ColorScale.ScaleWithColor(cmd.Color)
+			text.Draw(target, cmd.Text, face, op)
+		case *IconCommand:
+			img := iconEbitenImage(cmd.Icon)
+			if img == nil {
+				continue
+			}
+			op := &ebiten.DrawImageOptions{}
+			x := cmd.Rect.Min.X + (cmd.Rect.Dx()-img.Bounds().Dx())/2
+			y := cmd.Rect.Min.Y + (cmd.Rect.Dy()-img.Bounds().Dy())/2
+			pos := scalePoint(image.Pt(x, y), scale)
+			op.GeoM.Scale(scale, scale)
+			op.GeoM.Translate(float64(pos.X), float64(pos.Y))
+			op.ColorScale.ScaleWithColor(cmd.Color)
+			target.DrawImage(img, op)
+		case *DrawCommand:
+			cmd.Draw(target)
+		case *ClipCommand:
+			target = e.Target.SubImage(scaleRect(cmd.Rect, scale)).(*ebiten.Image)
+		}
+	}
+}
+
+func textCommandFace(cmd *TextCommand) text.Face {
+	if cmd.Face == nil {
+		return defaultFontFace
+	}
+	return cmd.Face
+}
+
+var (
+	iconEbitenImages  = map[Icon]*ebiten.Image{}
+	iconEbitenImagesM sync.Mutex
+)
+
+func iconEbitenImage(icon Icon) *ebiten.Image {
+	iconEbitenImagesM.Lock()
+	defer iconEbitenImagesM.Unlock()
+
+	if img, ok := iconEbitenImages[icon]; ok {
+		return img
+	}
+	src := icon.Image()
+	if src == nil {
+		return nil
+	}
+	iconEbitenImages[icon] = ebiten.NewImageFromImage(src)
+	return iconEbitenImages[icon]
+}
+
+// ImageRenderer is a Renderer that renders commands onto a draw.Image like *image.RGBA without a GPU.
+//
+// ImageRenderer is useful for tests and tools that cannot use Ebitengine's rendering.
+// ImageRenderer ignores DrawCommands, as they require Ebitengine's rendering.
+// ImageRenderer can render texts only with *text.GoXFace. Texts with other font faces are rendered with the default font face.
+type ImageRenderer struct {
+	// Target is the destination image.
+	Target draw.Image
+}
+
+// Render implements Renderer.
+func (i *ImageRenderer) Render(commands iter.Seq[Command], scale float64) {
+	clip := i.Target.Bounds()
+	for cmd := range commands {
+		switch cmd := cmd.(type) {
+		case *RectCommand:
+			r := scaleRect(cmd.Rect, scale).Intersect(clip)
+			draw.Draw(i.Target, r, image.NewUniform(cmd.Color), image.Point{}, draw.Over)
+		case *LineCommand:
+			i.drawLine(cmd, scale, clip)
+		case *TextCommand:
+			i.drawText(cmd, scale, clip)
+		case *IconCommand:
+			i.drawIcon(cmd, scale, clip)
+		case *ClipCommand:
+			clip = scaleRect(cmd.Rect, scale).Intersect(i.Target.Bounds())
+		}
+	}
+}
+
+// drawLine draws the line segment with Bresenham's algorithm in the UI coordinate.
+func (i *ImageRenderer) drawLine(cmd *LineCommand, scale float64, clip image.Rectangle) {
+	src := image.NewUniform(cmd.Color)
+	p, to := cmd.From, cmd.To
+	dx, dy := abs(to.X-p.X), -abs(to.Y-p.Y)
+	sx, sy := 1, 1
+	if p.X > to.X {
+		sx = -1
+	}
+	if p.Y > to.Y {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		r := scaleRect(image.Rect(p.X, p.Y, p.X+1, p.Y+1), scale).Intersect(clip)
+		draw.Draw(i.Target, r, src, image.Point{}, draw.Over)
+		if p == to {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			p.X += sx
+		}
+		if e2 <= dx {
+			e += dx
+			p.Y += sy
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func (i *ImageRenderer) drawText(cmd *TextCommand, scale float64, clip image.Rectangle) {
+	f, ok := textCommandFace(cmd).(*text.GoXFace)
+	if !ok {
+		f = defaultFontFace
+	}
+	face := f.UnsafeInternal()
+	mask := image.NewAlpha(image.Rect(0, 0, textWidth(cmd.Text, f), lineHeight(f)))
+	d := font.Drawer{
+		Dst:  mask,
+		Src:  image.Opaque,
+		Face: face,
+		Dot:  fixed.Point26_6{Y: face.Metrics().Ascent},
+	}
+	d.DrawString(cmd.Text)
+	i.drawMask(scaleMask(mask, scale), scalePoint(cmd.Position, scale), cmd.Color, clip)
+}
+
+func (i *ImageRenderer) drawIcon(cmd *IconCommand, scale float64, clip image.Rectangle) {
+	img := cmd.Icon.Image()
+	if img == nil {
+		return
+	}
+	b := img.Bounds()
+	x := cmd.Rect.Min.X + (cmd.Rect.Dx()-b.Dx())/2
+	y := cmd.Rect.Min.Y + (cmd.Rect.Dy()-b.Dy())/2
+
+	// Scale the icon's color with the command's color, in the same way as ebiten.ColorScale.
+	cr, cg, cb, ca := cmd.Color.RGBA()
+	pos := scalePoint(image.Pt(x, y), scale)
+	tinted := image.NewRGBA(scaleRect(image.Rect(x, y, x+b.Dx(), y+b.Dy()), scale).Sub(pos))
+	for j := 0; j < tinted.Bounds().Dy(); j++ {
+		for k := 0; k < tinted.Bounds().Dx(); k++ {
+			r, g, bl, a := img.At(b.Min.X+int(float64(k)/scale), b.Min.Y+int(float64(j)/scale)).RGBA()
+			tinted.SetRGBA64(k, j, color.RGBA64{
+				R: uint16(r * cr / 0xffff),
+				G: uint16(g * cg / 0xffff),
+				B: uint16(bl * cb / 0xffff),
+				A: uint16(a * ca / 0xffff),
+			})
+		}
+	}
+	r := tinted.Bounds().Add(pos).Intersect(clip)
+	draw.Draw(i.Target, r, tinted, r.Min.Sub(pos), draw.Over)
+}
+
+func (i *ImageRenderer) drawMask(mask *image.Alpha, pos image.Point, clr color.Color, clip image.Rectangle) {
+	r := mask.Bounds().Add(pos).Intersect(clip)
+	draw.DrawMask(i.Target, r, image.NewUniform(clr), image.Point{}, mask, r.Min.Sub(pos), draw.Over)
+}
+
+// scaleMask scales the mask with the nearest-neighbor filter.
+func scaleMask(mask *image.Alpha, scale float64) *image.Alpha {
+	if scale == 1 {
+		return mask
+	}
+	b := mask.Bounds()
+	dst := image.NewAlpha(image.Rect(0, 0, int(math.Round(float64(b.Dx())*scale)), int(math.Round(float64(b.Dy())*scale))))
+	for j := 0; j < dst.Bounds().Dy(); j++ {
+		for i := 0; i < dst.Bounds().Dx(); i++ {
+			dst.SetAlpha(i, j, mask.AlphaAt(b.Min.X+int(float64(i)/scale), b.Min.Y+int(float64(j)/scale)))
+		}
+	}
+	return dst
+}
@@ -24,7 +24,7 @@ import (
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
 func (c *Context) Slider(value *int, low, high int, step int) EventHandler {
 	pc := caller()
-	id := c.idStack.push(idPartFromCaller(pc))
+	id := c.idStack.push(c.nextIDPart(pc))
 	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
 		return c.slider(value, low, high, step, id, optionAlignCenter)
 	})
@@ -43,7 +43,7 @@ func (c *Context) Slider(value *int, low, high int, step int) EventHandler {
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
 func (c *Context) SliderF(value *float64, low, high float64, step float64, digits int) EventHandler {
 	pc := caller()
-	id := c.idStack.push(idPartFromCaller(pc))
+	id := c.idStack.push(c.nextIDPart(pc))
 	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
 		return c.sliderF(value, low, high, step, digits, id, optionAlignCenter)
 	})
@@ -56,6 +56,7 @@ func (c *Context) slider(value *int, low, high, step int, id widgetID, opt optio
 
 	last := *value
 	v := last
+	defer recordEdit(c, id, value, last)
 
 	if err := c.numberTextField(&v, id); err != nil {
 		return nil, err
@@ -68,13 +69,16 @@ func (c *Context) slider(value *int, low, high, step int, id widgetID, opt optio
 	return c.widget(id, opt, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
 		var e EventHandler
 		if c.focus == id && c.pointing.pressed() {
-			if w := bounds.Dx() - defaultStyle.thumbSize; w > 0 {
-				v = low + (c.pointingPosition().X-bounds.Min.X-defaultStyle.thumbSize/2)*(high-low+step)/w
+			if w := bounds.Dx() - c.style().ThumbSize; w > 0 {
+				v = low + (c.pointingPosition().X-bounds.Min.X-c.style().ThumbSize/2)*(high-low+step)/w
 			}
 			if step != 0 {
 				v = v / step * step
 			}
 		}
+		if c.navFocused(id) {
+			v += c.navAdjustment() * max(step, 1)
+		}
 		*value = clamp(v, low, high)
 		v = *value
 		if last != v {
@@ -82,16 +86,16 @@ func (c *Context) slider(value *int, low, high, step int, id widgetID, opt optio
 		}
 		return e
 	}, func(bounds image.Rectangle) {
-		c.drawWidgetFrame(id, bounds, colorBase, opt)
-		w := c.style().thumbSize
+		c.drawWidgetFrame(id, bounds, ColorBase, opt)
+		w := c.style().ThumbSize
 		var x int
 		if low < high {
 			x = int((v - low) * (bounds.Dx() - w) / (high - low))
 		}
 		thumb := image.Rect(bounds.Min.X+x, bounds.Min.Y, bounds.Min.X+x+w, bounds.Max.Y)
-		c.drawWidgetFrame(id, thumb, colorButton, opt)
+		c.drawWidgetFrame(id, thumb, ColorButton, opt)
 		text := fmt.Sprintf("%d", v)
-		c.drawWidgetText(text, bounds, colorText, opt)
+		c.drawWidgetText(text, bounds, ColorText, opt)
 	})
 }
 
@@ -102,6 +106,7 @@ func (c *Context) sliderF(value *float64, low, high, step float64, digits int, i
 
 	last := *value
 	v := last
+	defer recordEdit(c, id, value, last)
 
 	if err := c.numberTextFieldF(&v, id); err != nil {
 		return nil, err
@@ -114,13 +119,20 @@ func (c *Context) sliderF(value *float64, low, high, step float64, digits int, i
 	return c.widget(id, opt, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
 		var e EventHandler
 		if c.focus == id && c.pointing.pressed() {
-			if w := float64(bounds.Dx() - defaultStyle.thumbSize); w > 0 {
-				v = low + float64(c.pointingPosition().X-bounds.Min.X-defaultStyle.thumbSize/2)*(high-low+step)/w
+			if w := float64(bounds.Dx() - c.style().ThumbSize); w > 0 {
+				v = low + float64(c.pointingPosition().X-bounds.Min.X-c.style().ThumbSize/2)*(high-low+step)/w
 			}
 			if step != 0 {
 				v = math.Round(v/step) * step
 			}
 		}
+		if c.navFocused(id) {
+			d := step
+			if d == 0 {
+				d = (high - low) / 100
+			}
+			v += float64(c.navAdjustment()) * d
+		}
 		*value = clamp(v, low, high)
 		v = *value
 		if last != v {
@@ -128,21 +140,21 @@ func (c *Context) sliderF(value *float64, low, high, step float64, digits int, i
 		}
 		return e
 	}, func(bounds image.Rectangle) {
-		c.drawWidgetFrame(id, bounds, colorBase, opt)
-		w := c.style().thumbSize
+		c.drawWidgetFrame(id, bounds, ColorBase, opt)
+		w := c.style().ThumbSize
 		var x int
 		if low < high {
 			x = int((v - low) * float64(bounds.Dx()-w) / (high - low))
 		}
 		thumb := image.Rect(bounds.Min.X+x, bounds.Min.Y, bounds.Min.X+x+w, bounds.Max.Y)
-		c.drawWidgetFrame(id, thumb, colorButton, opt)
+		c.drawWidgetFrame(id, thumb, ColorButton, opt)
 		text := formatNumber(v, digits)
-		c.drawWidgetText(text, bounds, colorText, opt)
+		c.drawWidgetText(text, bounds, ColorText, opt)
 	})
 }
 
 func (c *Context) numberTextField(value *int, id widgetID) error {
-	if c.pointing.justPressed() && ebiten.IsKeyPressed(ebiten.KeyShift) && c.hover == id {
+	if c.pointing.justPressed() && c.keyboard.isKeyPressed(ebiten.KeyShift) && c.hover == id {
 		c.numberEdit = id
 		c.numberEditBuf = fmt.Sprintf("%d", *value)
 	}
@@ -166,7 +178,7 @@ func (c *Context) numberTextField(value *int, id widgetID) error {
 }
 
 func (c *Context) numberTextFieldF(value *float64, id widgetID) error {
-	if c.pointing.justPressed() && ebiten.IsKeyPressed(ebiten.KeyShift) && c.hover == id {
+	if c.pointing.justPressed() && c.keyboard.isKeyPressed(ebiten.KeyShift) && c.hover == id {
 		c.numberEdit = id
 		c.numberEditBuf = fmt.Sprintf(realFmt, *value)
 	}
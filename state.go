@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"maps"
+	"slices"
+)
+
+// stateVersion is the version of the state format written by SaveState.
+const stateVersion = 1
+
+// state is the persisted layout state of the debug UI.
+type state struct {
+	Version int           `json:"version"`
+	Windows []windowState `json:"windows"`
+}
+
+// windowState is the persisted state of a window.
+//
+// Windows in state are ordered by z-order, from back to front.
+type windowState struct {
+	Key       string          `json:"key"`
+	Bounds    rectState       `json:"bounds"`
+	Collapsed bool            `json:"collapsed"`
+	Scroll    pointState      `json:"scroll"`
+	Headers   map[string]bool `json:"headers,omitempty"`
+
+	// zOrder is the index in state.Windows.
+	zOrder int
+}
+
+type rectState struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type pointState struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+func (r rectState) rect() image.Rectangle {
+	return image.Rect(r.X, r.Y, r.X+r.Width, r.Y+r.Height)
+}
+
+func rectStateFromRect(r image.Rectangle) rectState {
+	return rectState{
+		X:      r.Min.X,
+		Y:      r.Min.Y,
+		Width:  r.Dx(),
+		Height: r.Dy(),
+	}
+}
+
+// SaveState writes the layout state of the windows to w in JSON.
+//
+// The state includes the bounds, the collapsed flag, the scroll offset and the z-order of each window,
+// and whether each header and tree node is expanded.
+// Windows are identified by their keys or titles, and headers and tree nodes are identified by their ID paths
+// like "Demo Window/Game Config". Windows without title bars like popups are not saved.
+// SaveState returns an error if two windows have the same key. See also [Context.SetNextKey].
+func (d *DebugUI) SaveState(w io.Writer) error {
+	return d.ctx.saveState(w)
+}
+
+// LoadState reads the layout state written by SaveState from r.
+//
+// The state is applied to each window, header and tree node when it is shown next time.
+// A window is moved to keep its title bar inside the screen.
+func (d *DebugUI) LoadState(r io.Reader) error {
+	return d.ctx.loadState(r)
+}
+
+func (c *Context) saveState(w io.Writer) error {
+	var s state
+	s.Version = stateVersion
+	for _, cnt := range c.rootContainers {
+		if cnt.key == "" {
+			continue
+		}
+		if slices.ContainsFunc(s.Windows, func(w windowState) bool {
+			return w.Key == cnt.key
+		}) {
+			return fmt.Errorf("debugui: duplicate window key %q: use SetNextKey to distinguish the windows", cnt.key)
+		}
+		headers := maps.Clone(cnt.pendingHeaderExpansions)
+		if len(cnt.headerExpansions) > 0 && headers == nil {
+			headers = map[string]bool{}
+		}
+		maps.Copy(headers, cnt.headerExpansions)
+		s.Windows = append(s.Windows, windowState{
+			Key:       cnt.key,
+			Bounds:    rectStateFromRect(cnt.layout.Bounds),
+			Collapsed: cnt.collapsed,
+			Scroll: pointState{
+				X: cnt.layout.ScrollOffset.X,
+				Y: cnt.layout.ScrollOffset.Y,
+			},
+			Headers: headers,
+		})
+	}
+
+	// Keep the states of the windows that have not been shown since LoadState.
+	for _, key := range slices.SortedFunc(maps.Keys(c.pendingWindowStates), func(a, b string) int {
+		return cmp.Compare(c.pendingWindowStates[a].zOrder, c.pendingWindowStates[b].zOrder)
+	}) {
+		s.Windows = append(s.Windows, *c.pendingWindowStates[key])
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	if err := e.Encode(&s); err != nil {
+		return fmt.Errorf("debugui: encoding state failed: %w", err)
+	}
+	return nil
+}
+
+func (c *Context) loadState(r io.Reader) error {
+	var s state
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return fmt.Errorf("debugui: decoding state failed: %w", err)
+	}
+	if s.Version != stateVersion {
+		return fmt.Errorf("debugui: unsupported state version: %d", s.Version)
+	}
+	windows := map[string]*windowState{}
+	for i, w := range s.Windows {
+		if _, ok := windows[w.Key]; ok {
+			return fmt.Errorf("debugui: duplicate window key in state: %q", w.Key)
+		}
+		if w.Bounds.Width <= 0 || w.Bounds.Height <= 0 {
+			return fmt.Errorf("debugui: empty bounds of window %q in state: %dx%d", w.Key, w.Bounds.Width, w.Bounds.Height)
+		}
+		w.zOrder = i
+		windows[w.Key] = &w
+	}
+	c.pendingWindowStates = windows
+	return nil
+}
+
+// restoreWindowState applies the loaded state to the root container cnt, if any.
+func (c *Context) restoreWindowState(cnt *container) {
+	if cnt.key == "" {
+		return
+	}
+	s, ok := c.pendingWindowStates[cnt.key]
+	if !ok {
+		return
+	}
+	delete(c.pendingWindowStates, cnt.key)
+
+	cnt.layout.Bounds = s.Bounds.rect()
+	// The screen might have become smaller since the state was saved.
+	if screen := c.screenSizeInUI(); screen.X > 0 && screen.Y > 0 {
+		b := cnt.layout.Bounds
+		var p image.Point
+		p.X = max(min(b.Min.X, screen.X-b.Dx()), 0)
+		p.Y = max(min(b.Min.Y, screen.Y-c.titleHeight()), 0)
+		cnt.layout.Bounds = b.Add(p.Sub(b.Min))
+	}
+	cnt.collapsed = s.Collapsed
+	cnt.layout.ScrollOffset = image.Pt(s.Scroll.X, s.Scroll.Y)
+	cnt.pendingHeaderExpansions = s.Headers
+	clear(cnt.headerExpansions)
+
+	if c.restoredZOrders == nil {
+		c.restoredZOrders = map[*container]int{}
+	}
+	c.restoredZOrders[cnt] = s.zOrder
+}
+
+// restoreZOrders reorders the root containers restored in this frame by their loaded z-orders.
+//
+// The other root containers keep their positions.
+func (c *Context) restoreZOrders() {
+	if len(c.restoredZOrders) == 0 {
+		return
+	}
+	var indices []int
+	var cnts []*container
+	for i, cnt := range c.rootContainers {
+		if _, ok := c.restoredZOrders[cnt]; !ok {
+			continue
+		}
+		indices = append(indices, i)
+		cnts = append(cnts, cnt)
+	}
+	slices.SortStableFunc(cnts, func(a, b *container) int {
+		return cmp.Compare(c.restoredZOrders[a], c.restoredZOrders[b])
+	})
+	for i, idx := range indices {
+		c.rootContainers[idx] = cnts[i]
+	}
+	clear(c.restoredZOrders)
+}
+
+// restoreHeaderExpansion applies the loaded expansion state to the header with the key.
+//
+// restoreHeaderExpansion returns the ID path of the header, or an empty string if the header cannot be persisted.
+func (c *Context) restoreHeaderExpansion(key string, id widgetID, initialExpansion bool) string {
+	root := c.currentRootContainer()
+	if root.key == "" {
+		return ""
+	}
+	path := c.keyPathString(key)
+	if expanded, ok := root.pendingHeaderExpansions[path]; ok {
+		delete(root.pendingHeaderExpansions, path)
+		if c.currentContainer().toggled(id) != (expanded != initialExpansion) {
+			c.currentContainer().toggle(id)
+		}
+	}
+	return path
+}
+
+// recordHeaderExpansion records whether the header at path is expanded.
+func (c *Context) recordHeaderExpansion(path string, expanded bool) {
+	if path == "" {
+		return
+	}
+	root := c.currentRootContainer()
+	if root.headerExpansions == nil {
+		root.headerExpansions = map[string]bool{}
+	}
+	root.headerExpansions[path] = expanded
+}
@@ -4,64 +4,217 @@
 package debugui
 
 import (
+	"errors"
 	"image/color"
 )
 
-type style struct {
-	defaultWidth  int
-	defaultHeight int
-	padding       int
-	spacing       int
-	indent        int
-	titleHeight   int
-	scrollbarSize int
-	thumbSize     int
-	colors        [colorCount]color.RGBA
+// Theme represents the colors and the metrics of the debug UI.
+type Theme struct {
+	// DefaultWidth is the default width of a widget.
+	DefaultWidth int
+
+	// DefaultHeight is the default height of a widget.
+	// If DefaultHeight is too small for the font face, a larger value is used.
+	DefaultHeight int
+
+	// Padding is the padding inside a widget or a container.
+	Padding int
+
+	// Spacing is the spacing between widgets.
+	Spacing int
+
+	// Indent is the indent of the content of a tree node.
+	// If Indent is smaller than the line height of the font face, the line height is used.
+	Indent int
+
+	// TitleHeight is the height of a window's title bar.
+	// If TitleHeight is too small for the font face, a larger value is used.
+	TitleHeight int
+
+	// ScrollbarSize is the width of a scroll bar.
+	ScrollbarSize int
+
+	// ThumbSize is the size of a slider's thumb.
+	ThumbSize int
+
+	// Colors is the colors of the UI, indexed by ColorSlot.
+	Colors [ColorSlotCount]color.RGBA
 }
 
+// ColorSlot represents a kind of color in a Theme.
+type ColorSlot int
+
 const (
-	colorText = iota
-	colorBorder
-	colorWindowBG
-	colorTitleBG
-	colorTitleBGTransparent
-	colorTitleText
-	colorPanelBG
-	colorButton
-	colorButtonHover
-	colorButtonFocus
-	colorBase
-	colorBaseHover
-	colorBaseFocus
-	colorScrollBase
-	colorScrollThumb
-	colorCount
+	// ColorText is the color of texts.
+	ColorText ColorSlot = iota
+
+	// ColorBorder is the color of borders. If the alpha is 0, borders are not drawn.
+	ColorBorder
+
+	// ColorWindowBG is the background color of windows.
+	ColorWindowBG
+
+	// ColorTitleBG is the background color of title bars.
+	ColorTitleBG
+
+	// ColorTitleBGTransparent is the background color of title bars of collapsed windows.
+	ColorTitleBGTransparent
+
+	// ColorTitleText is the color of titles.
+	ColorTitleText
+
+	// ColorPanelBG is the background color of panels.
+	ColorPanelBG
+
+	// ColorButton is the color of buttons and headers.
+	ColorButton
+
+	// ColorButtonHover is the color of hovered buttons and headers.
+	ColorButtonHover
+
+	// ColorButtonFocus is the color of focused buttons and headers.
+	ColorButtonFocus
+
+	// ColorBase is the color of the base of widgets like text fields and sliders.
+	ColorBase
+
+	// ColorBaseHover is the color of the base of hovered widgets.
+	ColorBaseHover
+
+	// ColorBaseFocus is the color of the base of focused widgets.
+	ColorBaseFocus
+
+	// ColorScrollBase is the color of the base of scroll bars.
+	ColorScrollBase
+
+	// ColorScrollThumb is the color of the thumbs of scroll bars.
+	ColorScrollThumb
+
+	// ColorFocusRing is the color of the ring around the widget with the keyboard navigation focus.
+	ColorFocusRing
+
+	// ColorSelection is the background color of selected texts.
+	ColorSelection
+
+	// ColorPlotGrid is the color of the grid lines of plots.
+	ColorPlotGrid
+
+	// ColorModalDim is the color to dim the screen behind modal windows.
+	ColorModalDim
+
+	// ColorSlotCount is the number of color slots.
+	ColorSlotCount
 )
 
-var defaultStyle style = style{
-	defaultWidth:  60,
-	defaultHeight: 18,
-	padding:       5,
-	spacing:       4,
-	indent:        lineHeight(),
-	titleHeight:   24,
-	scrollbarSize: 12,
-	thumbSize:     8,
-	colors: [...]color.RGBA{
-		colorText:               {230, 230, 230, 255},
-		colorBorder:             {60, 60, 60, 255},
-		colorWindowBG:           {45, 45, 45, 230},
-		colorTitleBG:            {30, 30, 30, 255},
-		colorTitleBGTransparent: {20, 20, 20, 204},
-		colorTitleText:          {240, 240, 240, 255},
-		colorPanelBG:            {0, 0, 0, 0},
-		colorButton:             {75, 75, 75, 255},
-		colorButtonHover:        {95, 95, 95, 255},
-		colorButtonFocus:        {115, 115, 115, 255},
-		colorBase:               {30, 30, 30, 255},
-		colorBaseHover:          {35, 35, 35, 255},
-		colorBaseFocus:          {40, 40, 40, 255},
-		colorScrollBase:         {43, 43, 43, 255},
-		colorScrollThumb:        {30, 30, 30, 255},
+var defaultTheme = Theme{
+	DefaultWidth:  60,
+	DefaultHeight: 18,
+	Padding:       5,
+	Spacing:       4,
+	Indent:        lineHeight(defaultFontFace),
+	TitleHeight:   24,
+	ScrollbarSize: 12,
+	ThumbSize:     8,
+	Colors: [...]color.RGBA{
+		ColorText:               {230, 230, 230, 255},
+		ColorBorder:             {60, 60, 60, 255},
+		ColorWindowBG:           {45, 45, 45, 230},
+		ColorTitleBG:            {30, 30, 30, 255},
+		ColorTitleBGTransparent: {20, 20, 20, 204},
+		ColorTitleText:          {240, 240, 240, 255},
+		ColorPanelBG:            {0, 0, 0, 0},
+		ColorButton:             {75, 75, 75, 255},
+		ColorButtonHover:        {95, 95, 95, 255},
+		ColorButtonFocus:        {115, 115, 115, 255},
+		ColorBase:               {30, 30, 30, 255},
+		ColorBaseHover:          {35, 35, 35, 255},
+		ColorBaseFocus:          {40, 40, 40, 255},
+		ColorScrollBase:         {43, 43, 43, 255},
+		ColorScrollThumb:        {30, 30, 30, 255},
+		ColorFocusRing:          {80, 150, 230, 255},
+		ColorSelection:          {50, 90, 150, 255},
+		ColorPlotGrid:           {60, 60, 60, 255},
+		ColorModalDim:           {0, 0, 0, 128},
 	},
 }
+
+// DefaultTheme returns a copy of the default theme.
+func DefaultTheme() *Theme {
+	t := defaultTheme
+	return &t
+}
+
+// SetTheme sets the theme of the UI.
+//
+// theme is copied, so modifying theme after SetTheme doesn't affect the UI.
+// If theme is nil, the default theme is used.
+func (c *Context) SetTheme(theme *Theme) {
+	if theme == nil {
+		c.theme = nil
+		return
+	}
+	t := *theme
+	c.theme = &t
+}
+
+// Theme returns a copy of the current theme.
+//
+// If a style is pushed by PushStyle, Theme returns the pushed style.
+func (c *Context) Theme() *Theme {
+	t := *c.style()
+	return &t
+}
+
+// PushStyle pushes the style to the style stack.
+// The style is used for the windows and the widgets until PopStyle is called.
+//
+// style is copied, so modifying style after PushStyle doesn't affect the UI.
+// A typical usage is to modify a part of the current theme:
+//
+//	style := ctx.Theme()
+//	style.Colors[debugui.ColorText] = color.RGBA{0xff, 0, 0, 0xff}
+//	ctx.PushStyle(style)
+//	ctx.Text("Warning")
+//	ctx.PopStyle()
+func (c *Context) PushStyle(style *Theme) {
+	s := *style
+	c.styleStack = append(c.styleStack, &s)
+}
+
+// PopStyle pops the style pushed by PushStyle.
+//
+// If no style is pushed, Update returns an error.
+func (c *Context) PopStyle() {
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		if len(c.styleStack) == 0 {
+			return nil, errors.New("debugui: style stack is empty")
+		}
+		c.styleStack = c.styleStack[:len(c.styleStack)-1]
+		return nil, nil
+	})
+}
+
+// defaultHeight returns the default height of a widget, enlarged to fit the font face.
+func (c *Context) defaultHeight() int {
+	return max(c.style().DefaultHeight, c.lineHeight()+defaultTheme.DefaultHeight-lineHeight(defaultFontFace))
+}
+
+// titleHeight returns the height of a window's title bar, enlarged to fit the font face.
+func (c *Context) titleHeight() int {
+	return max(c.style().TitleHeight, c.lineHeight()+defaultTheme.TitleHeight-lineHeight(defaultFontFace))
+}
+
+// indent returns the indent of the content of a tree node, enlarged to fit the font face.
+func (c *Context) indent() int {
+	return max(c.style().Indent, c.lineHeight())
+}
+
+func (c *Context) style() *Theme {
+	if len(c.styleStack) > 0 {
+		return c.styleStack[len(c.styleStack)-1]
+	}
+	if c.theme != nil {
+		return c.theme
+	}
+	return &defaultTheme
+}
@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"slices"
+)
+
+// TabBarID is the ID of a tab bar.
+type TabBarID widgetID
+
+// tab is a tab added by Tab.
+type tab struct {
+	id     widgetID
+	idPart string
+	label  string
+	f      func()
+}
+
+// tabBarState is the state of a tab bar kept by its container.
+type tabBarState struct {
+	// selected is the ID of the selected tab.
+	selected widgetID
+
+	// scroll is the scroll offset of the tabs in pixels.
+	scroll int
+}
+
+// TabBar creates a tab bar with the tabs defined by the function f, and returns the TabBarID of the tab bar.
+//
+// f must add the tabs by calling [Context.Tab].
+// The tab bar shows the tabs in a row, and only the content of the selected tab is rendered below the row.
+// The first tab is selected initially, and the selected tab is remembered by the container of the tab bar.
+// If the tabs don't fit in the row, the tabs can be scrolled by the arrow buttons.
+//
+// A TabBar widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) TabBar(f func()) TabBarID {
+	pc := caller()
+	idPart := c.nextIDPart(pc)
+	id := c.idStack.push(idPart)
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		var err error
+		c.idScopeFromIDPart(idPart, func(id widgetID) {
+			err = c.tabBar(id, f)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	return TabBarID(id)
+}
+
+// Tab adds a tab with the given label to the tab bar.
+// f is called to render the content of the tab, only when the tab is selected.
+//
+// Tab must be called in the function of [Context.TabBar].
+//
+// A Tab is uniquely determined by its call location in the tab bar.
+// If you want to generate different tabs with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) Tab(label string, f func()) {
+	pc := caller()
+	idPart := c.nextIDPart(pc)
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		if c.tabs == nil {
+			return nil, errors.New("debugui: Tab must be called in the function of TabBar")
+		}
+		*c.tabs = append(*c.tabs, tab{
+			id:     c.idStack.push(idPart),
+			idPart: idPart,
+			label:  label,
+			f:      f,
+		})
+		return nil, nil
+	})
+}
+
+// SelectTab selects the tab at the index in the tab bar.
+//
+// The selection is applied when the tab bar is created next time.
+// If SelectTab is called after TabBar in a frame, the selection is applied in the next frame.
+func (c *Context) SelectTab(tabBarID TabBarID, index int) {
+	if c.tabSelections == nil {
+		c.tabSelections = map[widgetID]int{}
+	}
+	c.tabSelections[widgetID(tabBarID)] = index
+}
+
+func (c *Context) tabBar(id widgetID, f func()) error {
+	var tabs []tab
+	origTabs := c.tabs
+	c.tabs = &tabs
+	f()
+	c.tabs = origTabs
+	if len(tabs) == 0 {
+		return nil
+	}
+
+	state := c.currentContainer().tabBarState(id)
+	selected := max(slices.IndexFunc(tabs, func(t tab) bool {
+		return t.id == state.selected
+	}), 0)
+	index, selectedByAPI := c.tabSelections[id]
+	if selectedByAPI {
+		delete(c.tabSelections, id)
+		if index < 0 || index >= len(tabs) {
+			return fmt.Errorf("debugui: tab index (%d) out of range [0, %d)", index, len(tabs))
+		}
+		selected = index
+	}
+
+	if err := c.setGridLayout(nil, nil); err != nil {
+		return err
+	}
+	bounds, err := c.layoutNext()
+	if err != nil {
+		return err
+	}
+
+	// Lay out the tabs in a row.
+	starts := make([]int, len(tabs))
+	widths := make([]int, len(tabs))
+	var x int
+	for i, t := range tabs {
+		starts[i] = x
+		widths[i] = c.textWidth(t.label) + 2*c.style().Padding
+		x += widths[i] + 1
+	}
+	total := x - 1
+
+	// Show the arrow buttons if the tabs overflow.
+	area := bounds
+	if total > bounds.Dx() {
+		area.Max.X -= 2 * bounds.Dy()
+		left := image.Rect(area.Max.X, bounds.Min.Y, area.Max.X+bounds.Dy(), bounds.Max.Y)
+		right := image.Rect(left.Max.X, bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
+		if c.tabBarArrow(id.push(idPartFromString("left")), left, IconLeft) {
+			// Scroll to the previous tab.
+			for i := len(starts) - 1; i >= 0; i-- {
+				if starts[i] < state.scroll {
+					state.scroll = starts[i]
+					break
+				}
+			}
+		}
+		if c.tabBarArrow(id.push(idPartFromString("right")), right, IconRight) {
+			// Scroll to the next tab.
+			for _, s := range starts {
+				if s > state.scroll {
+					state.scroll = s
+					break
+				}
+			}
+		}
+	}
+	// scrollTo scrolls the tabs to show the i-th tab.
+	scrollTo := func(i int) {
+		if starts[i] < state.scroll {
+			state.scroll = starts[i]
+		} else if end := starts[i] + widths[i]; end > state.scroll+area.Dx() {
+			state.scroll = end - area.Dx()
+		}
+	}
+	if selectedByAPI {
+		scrollTo(selected)
+	}
+	// Scroll to the tab with the navigation focus, as the focus can move to a tab scrolled out.
+	if i := slices.IndexFunc(tabs, func(t tab) bool {
+		return c.navFocused(t.id)
+	}); i >= 0 {
+		scrollTo(i)
+	}
+	state.scroll = clamp(state.scroll, 0, max(total-area.Dx(), 0))
+
+	// Draw the tabs.
+	tabBounds := func(i int) image.Rectangle {
+		x := area.Min.X + starts[i] - state.scroll
+		return image.Rect(x, area.Min.Y, x+widths[i], area.Max.Y)
+	}
+	c.pushClipRect(area)
+	var clicked bool
+	var visibleTabs []int
+	for i, t := range tabs {
+		// All the tabs can get the navigation focus, even if they are scrolled out.
+		c.registerFocusable(t.id, tabBounds(i), 0)
+		r := tabBounds(i)
+		_ = c.widgetWithBounds(t.id, 0, r, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+			if (c.pointing.justPressed() && c.focus == t.id) || c.navActivated(t.id) {
+				selected = i
+				clicked = true
+			}
+			return nil
+		}, func(bounds image.Rectangle) {
+			if i == selected {
+				c.drawFrame(bounds, ColorButtonFocus)
+			} else {
+				c.drawWidgetFrame(t.id, bounds, ColorButton, 0)
+			}
+			c.drawWidgetText(t.label, bounds, ColorText, optionAlignCenter)
+		})
+		if !r.Intersect(c.clipRect()).Empty() {
+			visibleTabs = append(visibleTabs, i)
+		}
+	}
+	c.popClipRect()
+	// Draw the focus ring outside the clipping area of the tabs.
+	for _, i := range visibleTabs {
+		c.drawFocusRing(tabs[i].id, tabBounds(i).Intersect(area))
+	}
+
+	// A tab partially scrolled out is fully shown from the next frame.
+	if clicked {
+		scrollTo(selected)
+	}
+	state.selected = tabs[selected].id
+
+	t := tabs[selected]
+	key := t.label
+	if k, ok := keyFromID(t.id); ok {
+		key = k
+	}
+	c.pushKeyPath(key)
+	defer c.popKeyPath()
+	c.idScopeFromIDPart(t.idPart, func(widgetID) {
+		t.f()
+	})
+	return nil
+}
+
+// tabBarArrow creates an arrow button to scroll the tabs, and reports whether the button is pressed.
+func (c *Context) tabBarArrow(id widgetID, bounds image.Rectangle, icon Icon) bool {
+	var pressed bool
+	_ = c.widgetWithBounds(id, optionNoNavigation, bounds, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+		pressed = c.pointing.justPressed() && c.focus == id
+		return nil
+	}, func(bounds image.Rectangle) {
+		c.drawWidgetFrame(id, bounds, ColorButton, 0)
+		c.drawIcon(icon, bounds, c.style().Colors[ColorText])
+	})
+	return pressed
+}
+
+func (c *container) tabBarState(id widgetID) *tabBarState {
+	if s, ok := c.tabBars[id]; ok {
+		return s
+	}
+	if c.tabBars == nil {
+		c.tabBars = map[widgetID]*tabBarState{}
+	}
+	s := &tabBarState{}
+	c.tabBars[id] = s
+	return s
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	"github.com/rivo/uniseg"
 )
 
@@ -16,44 +17,55 @@ func removeSpaceAtLineTail(str string) string {
 	return strings.TrimRightFunc(str, unicode.IsSpace)
 }
 
-func lines(text string, width int) iter.Seq[string] {
+func lines(text string, width int, face text.Face) iter.Seq[string] {
 	return func(yield func(string) bool) {
-		var line string
-		var word string
+		for start, end := range lineRanges(text, width, face) {
+			if !yield(text[start:end]) {
+				return
+			}
+		}
+	}
+}
+
+// lineRanges returns the byte ranges of the lines of text wrapped at width.
+//
+// The spaces and the line breaks at the tail of each line are not included in the ranges.
+func lineRanges(text string, width int, face text.Face) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		// The current line is text[lineStart:wordStart], and the current word is text[wordStart:pos].
+		var lineStart, wordStart, pos int
+		lineEnd := func(end int) int {
+			return lineStart + len(removeSpaceAtLineTail(text[lineStart:end]))
+		}
 		state := -1
-		for len(text) > 0 {
-			cluster, nextText, boundaries, nextState := uniseg.StepString(text, state)
+		rest := text
+		for len(rest) > 0 {
+			cluster, nextRest, boundaries, nextState := uniseg.StepString(rest, state)
+			next := pos + len(cluster)
 			switch m := boundaries & uniseg.MaskLine; m {
-			default:
-				word += cluster
 			case uniseg.LineCanBreak, uniseg.LineMustBreak:
-				if line == "" {
-					line += word + cluster
-				} else {
-					if l := removeSpaceAtLineTail(line + word + cluster); textWidth(l) > width {
-						if !yield(removeSpaceAtLineTail(line)) {
-							return
-						}
-						line = word + cluster
-					} else {
-						line += word + cluster
+				if lineStart != wordStart && textWidth(removeSpaceAtLineTail(text[lineStart:next]), face) > width {
+					if !yield(lineStart, lineEnd(wordStart)) {
+						return
 					}
+					lineStart = wordStart
 				}
-				word = ""
+				wordStart = next
 				if m == uniseg.LineMustBreak {
-					if !yield(removeSpaceAtLineTail(line)) {
+					if !yield(lineStart, lineEnd(next)) {
 						return
 					}
-					line = ""
+					lineStart = next
+					wordStart = next
 				}
 			}
+			pos = next
 			state = nextState
-			text = nextText
+			rest = nextRest
 		}
 
-		line += word
-		if len(line) > 0 {
-			if !yield(removeSpaceAtLineTail(line)) {
+		if pos > lineStart {
+			if !yield(lineStart, lineEnd(pos)) {
 				return
 			}
 		}
@@ -63,9 +75,9 @@ func lines(text string, width int) iter.Seq[string] {
 // Text creates a text label.
 func (c *Context) Text(text string) {
 	c.GridCell(func(bounds image.Rectangle) {
-		for line := range lines(text, bounds.Dx()-c.style().padding) {
+		for line := range lines(text, bounds.Dx()-c.style().Padding, c.FontFace()) {
 			_, _ = c.widget(widgetID{}, 0, nil, nil, func(bounds image.Rectangle) {
-				c.drawWidgetText(line, bounds, colorText, 0)
+				c.drawWidgetText(line, bounds, ColorText, 0)
 			})
 		}
 	})
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"fmt"
+	"image"
+	"os"
+)
+
+// TextArea creates a multi-line text area to modify the value of a string buf.
+//
+// The text is wrapped at the width of the text area. If the text is longer than the text area, the text area can be scrolled vertically.
+// The height of the text area is determined by the grid layout. See [Context.SetGridLayout].
+//
+// The caret and the selection can be moved by the arrow keys, Home, End and the pointing device with Shift.
+// Ctrl+A, Ctrl+C, Ctrl+X and Ctrl+V select all, copy, cut and paste the text. See [DebugUI.SetClipboard].
+//
+// TextArea returns an EventHandler to handle events when the value is confirmed on blur.
+// A returned EventHandler is never nil.
+//
+// A TextArea widget is uniquely determined by its call location.
+// Function calls made in different locations will create different widgets.
+// If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
+func (c *Context) TextArea(buf *string) EventHandler {
+	pc := caller()
+	idPart := c.nextIDPart(pc)
+	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		return c.textArea(buf, idPart)
+	})
+}
+
+func (c *Context) textArea(buf *string, idPart string) (EventHandler, error) {
+	var e EventHandler
+	var err error
+	c.idScopeFromIDPart(idPart, func(id widgetID) {
+		defer recordEdit(c, id, buf, *buf)
+		bounds, err1 := c.layoutNext()
+		if err1 != nil {
+			err = err1
+			return
+		}
+		c.drawWidgetFrame(id, bounds, ColorBase, 0)
+		err = c.doPanelWithBounds(optionNoFrame|optionNoKeyPath, id, bounds, func(layout ContainerLayout) {
+			e, err = c.textAreaContent(buf, id, layout)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// textAreaContent creates the content of the text area, which is laid out in the text area's scrollable container.
+func (c *Context) textAreaContent(buf *string, id widgetID, layout ContainerLayout) (EventHandler, error) {
+	cnt := c.currentContainer()
+	f := cnt.textInputTextField(id, true)
+	width := layout.BodyBounds.Dx() - 2*c.style().Padding
+
+	l := newTextLayout(*buf, width, c.FontFace())
+	// Fill the text area so that clicking anywhere in the text area focuses it.
+	c.SetGridLayout(nil, []int{max(l.height(), layout.Bounds.Dy()-2*c.style().Padding)})
+
+	return c.widget(id, optionHoldFocus, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
+		var e EventHandler
+
+		if c.focus != id {
+			if *buf != f.Text() {
+				f.SetTextAndSelection(*buf, len(*buf), len(*buf))
+			}
+			if wasFocused {
+				e = &eventHandler{}
+			}
+			// Enter or the gamepad's A button starts editing the text area with the navigation focus.
+			if c.navFocused(id) && c.navConfirmed() {
+				c.setFocus(id)
+			}
+			return e
+		}
+
+		sel := cnt.textSelection(id, f)
+		sel, moved := c.handleTextEditPointing(l, bounds.Min, sel)
+
+		// handle text input
+		pos := bounds.Min.Add(l.caretPosition(sel.caret))
+		handled, err := c.handleTextInput(f, pos.X, pos.Y+l.lineHeight)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return nil
+		}
+		str := f.Text()
+		if handled {
+			sel = cnt.textSelection(id, f)
+			moved = true
+		} else {
+			var changed bool
+			str, sel, changed = c.handleTextEditKeys(l, sel, true)
+			moved = moved || changed
+		}
+		if moved {
+			cnt.setTextAndSelection(id, f, str, sel)
+			*buf = str
+			l = newTextLayout(str, width, c.FontFace())
+			c.scrollToCaret(cnt, l, sel.caret)
+		}
+		return e
+	}, func(bounds image.Rectangle) {
+		if c.focus != id {
+			c.drawTextLayout(l, bounds.Min, ColorText)
+			return
+		}
+		sel := cnt.textSelection(id, f)
+		// Show the text being composed with an IME.
+		if str := f.TextForRendering(); str != l.text {
+			l = newTextLayout(str, width, c.FontFace())
+		}
+		c.drawTextSelection(l, bounds.Min, sel)
+		c.drawTextLayout(l, bounds.Min, ColorText)
+		c.drawCaret(l, bounds.Min, sel.caret)
+	})
+}
+
+// drawTextLayout draws the lines in l whose upper-left corner is at origin.
+func (c *Context) drawTextLayout(l *textLayout, origin image.Point, colorSlot ColorSlot) {
+	for i, line := range l.lines {
+		c.drawText(l.text[line.start:line.end], origin.Add(image.Pt(0, i*l.lineHeight)), c.style().Colors[colorSlot])
+	}
+}
+
+// scrollToCaret scrolls the container cnt so that the caret at pos in l is visible.
+func (c *Context) scrollToCaret(cnt *container, l *textLayout, pos int) {
+	y := l.caretPosition(pos).Y
+	h := cnt.layout.BodyBounds.Dy() - 2*c.style().Padding
+	if y < cnt.layout.ScrollOffset.Y {
+		cnt.layout.ScrollOffset.Y = y
+	}
+	if y+l.lineHeight > cnt.layout.ScrollOffset.Y+h {
+		cnt.layout.ScrollOffset.Y = y + l.lineHeight - h
+	}
+}
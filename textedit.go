@@ -0,0 +1,320 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// textSelection is the selection of a text editing widget in bytes.
+//
+// anchor is the fixed end of the selection, and caret is the end moved by the keys and the pointing device.
+// If anchor and caret are the same, nothing is selected.
+type textSelection struct {
+	anchor int
+	caret  int
+}
+
+func (s textSelection) ordered() (start, end int) {
+	return min(s.anchor, s.caret), max(s.anchor, s.caret)
+}
+
+func (s textSelection) empty() bool {
+	return s.anchor == s.caret
+}
+
+// textSelection returns the selection of the text editing widget.
+//
+// The selection of the textinput.Field is the source of truth. The recorded direction is used only if it matches the field.
+func (c *container) textSelection(id widgetID, f *textinput.Field) textSelection {
+	start, end := f.Selection()
+	if s, ok := c.textSelections[id]; ok {
+		if s0, s1 := s.ordered(); s0 == start && s1 == end {
+			return s
+		}
+	}
+	return textSelection{
+		anchor: start,
+		caret:  end,
+	}
+}
+
+// setTextAndSelection sets the text and the selection of the text editing widget.
+func (c *container) setTextAndSelection(id widgetID, f *textinput.Field, text string, s textSelection) {
+	start, end := s.ordered()
+	f.SetTextAndSelection(text, start, end)
+	if c.textSelections == nil {
+		c.textSelections = map[widgetID]textSelection{}
+	}
+	c.textSelections[id] = s
+}
+
+// textLine is a line laid out in a text editing widget.
+type textLine struct {
+	// start and end are the byte range of the visible part of the line.
+	start int
+	end   int
+
+	// next is the start of the next line, or the length of the text for the last line.
+	next int
+}
+
+// textLayout is the layout of the text in a text editing widget.
+type textLayout struct {
+	text       string
+	lines      []textLine
+	face       text.Face
+	lineHeight int
+}
+
+// newTextLayout lays out the text.
+//
+// If width is positive, the text is wrapped at width. Otherwise, the text is laid out in one line.
+func newTextLayout(str string, width int, face text.Face) *textLayout {
+	l := &textLayout{
+		text:       str,
+		face:       face,
+		lineHeight: lineHeight(face),
+	}
+	if width <= 0 {
+		l.lines = []textLine{{start: 0, end: len(str), next: len(str)}}
+		return l
+	}
+	for start, end := range lineRanges(str, width, face) {
+		if n := len(l.lines); n > 0 {
+			l.lines[n-1].next = start
+		}
+		l.lines = append(l.lines, textLine{start: start, end: end, next: len(str)})
+	}
+	// A caret can be at the empty line after the last line break.
+	if len(l.lines) == 0 || strings.HasSuffix(str, "\n") {
+		l.lines = append(l.lines, textLine{start: len(str), end: len(str), next: len(str)})
+	}
+	return l
+}
+
+// height returns the height of the laid out text.
+func (l *textLayout) height() int {
+	return len(l.lines) * l.lineHeight
+}
+
+// lineIndex returns the index of the line where the caret at pos is shown.
+func (l *textLayout) lineIndex(pos int) int {
+	for i := len(l.lines) - 1; i > 0; i-- {
+		if l.lines[i].start <= pos {
+			return i
+		}
+	}
+	return 0
+}
+
+// lineEnd returns the last caret position in the i-th line.
+func (l *textLayout) lineEnd(i int) int {
+	line := l.lines[i]
+	if i == len(l.lines)-1 {
+		return len(l.text)
+	}
+	if line.next > 0 && l.text[line.next-1] == '\n' {
+		return line.next - 1
+	}
+	return line.end
+}
+
+// caretPosition returns the position of the caret at pos, relative to the upper-left corner of the text.
+func (l *textLayout) caretPosition(pos int) image.Point {
+	i := l.lineIndex(pos)
+	pos = min(pos, l.lineEnd(i))
+	return image.Pt(textWidth(l.text[l.lines[i].start:pos], l.face), i*l.lineHeight)
+}
+
+// positionAt returns the caret position nearest to the point p relative to the upper-left corner of the text.
+func (l *textLayout) positionAt(p image.Point) int {
+	i := clamp(p.Y/l.lineHeight, 0, len(l.lines)-1)
+	return l.positionInLineAt(i, p.X)
+}
+
+// positionInLineAt returns the caret position in the i-th line nearest to x.
+func (l *textLayout) positionInLineAt(i int, x int) int {
+	start := l.lines[i].start
+	end := l.lineEnd(i)
+	pos := start
+	var w int
+	for pos < end {
+		_, size := utf8.DecodeRuneInString(l.text[pos:end])
+		nw := textWidth(l.text[start:pos+size], l.face)
+		if x < (w+nw)/2 {
+			break
+		}
+		pos += size
+		w = nw
+	}
+	return pos
+}
+
+func prevRuneBoundary(str string, pos int) int {
+	if pos <= 0 {
+		return 0
+	}
+	_, size := utf8.DecodeLastRuneInString(str[:pos])
+	return pos - size
+}
+
+func nextRuneBoundary(str string, pos int) int {
+	if pos >= len(str) {
+		return len(str)
+	}
+	_, size := utf8.DecodeRuneInString(str[pos:])
+	return pos + size
+}
+
+// handleTextEditKeys handles the keys to move the caret and to edit the text in l.
+//
+// Ctrl+A, Ctrl+C, Ctrl+X and Ctrl+V select all, copy, cut and paste the text with the clipboard.
+// Cmd is available instead of Ctrl on macOS.
+// If multiline is true, Up, Down and Enter are handled too. Otherwise, line breaks in a pasted text are replaced with spaces.
+// handleTextEditKeys returns the new text and the new selection, and whether the text or the selection is changed.
+func (c *Context) handleTextEditKeys(l *textLayout, sel textSelection, multiline bool) (string, textSelection, bool) {
+	str := l.text
+	shift := c.keyboard.isKeyPressed(ebiten.KeyShift)
+	ctrl := c.keyboard.isKeyPressed(ebiten.KeyControl) || c.keyboard.isKeyPressed(ebiten.KeyMeta)
+	moveTo := func(pos int) {
+		sel.caret = pos
+		if !shift {
+			sel.anchor = pos
+		}
+	}
+	replace := func(start, end int, s string) {
+		str = str[:start] + s + str[end:]
+		sel.caret = start + len(s)
+		sel.anchor = sel.caret
+	}
+
+	orig := sel
+	start, end := sel.ordered()
+	i := l.lineIndex(sel.caret)
+	switch {
+	case ctrl && c.keyboard.isKeyJustPressed(ebiten.KeyA):
+		sel = textSelection{
+			anchor: 0,
+			caret:  len(str),
+		}
+	case ctrl && (c.keyboard.isKeyJustPressed(ebiten.KeyC) || c.keyboard.isKeyJustPressed(ebiten.KeyX)):
+		if sel.empty() {
+			break
+		}
+		if err := c.currentClipboard().WriteText(str[start:end]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			break
+		}
+		if c.keyboard.isKeyJustPressed(ebiten.KeyX) {
+			replace(start, end, "")
+		}
+	case ctrl && c.keyboard.keyRepeated(ebiten.KeyV):
+		s, err := c.currentClipboard().ReadText()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			break
+		}
+		if !multiline {
+			s = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(s)
+		}
+		replace(start, end, s)
+	case c.keyboard.keyRepeated(ebiten.KeyLeft):
+		if !sel.empty() && !shift {
+			moveTo(start)
+		} else {
+			moveTo(prevRuneBoundary(str, sel.caret))
+		}
+	case c.keyboard.keyRepeated(ebiten.KeyRight):
+		if !sel.empty() && !shift {
+			moveTo(end)
+		} else {
+			moveTo(nextRuneBoundary(str, sel.caret))
+		}
+	case multiline && c.keyboard.keyRepeated(ebiten.KeyUp):
+		if i == 0 {
+			moveTo(0)
+		} else {
+			moveTo(l.positionInLineAt(i-1, l.caretPosition(sel.caret).X))
+		}
+	case multiline && c.keyboard.keyRepeated(ebiten.KeyDown):
+		if i == len(l.lines)-1 {
+			moveTo(len(str))
+		} else {
+			moveTo(l.positionInLineAt(i+1, l.caretPosition(sel.caret).X))
+		}
+	case c.keyboard.keyRepeated(ebiten.KeyHome):
+		moveTo(l.lines[i].start)
+	case c.keyboard.keyRepeated(ebiten.KeyEnd):
+		moveTo(l.lineEnd(i))
+	case c.keyboard.keyRepeated(ebiten.KeyBackspace):
+		if sel.empty() {
+			start = prevRuneBoundary(str, sel.caret)
+		}
+		replace(start, end, "")
+	case c.keyboard.keyRepeated(ebiten.KeyDelete):
+		if sel.empty() {
+			end = nextRuneBoundary(str, sel.caret)
+		}
+		replace(start, end, "")
+	case multiline && c.keyboard.keyRepeated(ebiten.KeyEnter):
+		replace(start, end, "\n")
+	}
+	return str, sel, str != l.text || sel != orig
+}
+
+// handleTextEditPointing moves the caret and the selection by the pointing device.
+//
+// origin is the upper-left corner of the text in l.
+// handleTextEditPointing must be called only when the text editing widget is focused.
+func (c *Context) handleTextEditPointing(l *textLayout, origin image.Point, sel textSelection) (textSelection, bool) {
+	if !c.pointing.pressed() {
+		return sel, false
+	}
+	orig := sel
+	pos := l.positionAt(c.pointingPosition().Sub(origin))
+	sel.caret = pos
+	// Pressing starts a new selection, and dragging extends the selection.
+	if c.pointing.justPressed() && !c.keyboard.isKeyPressed(ebiten.KeyShift) {
+		sel.anchor = pos
+	}
+	return sel, sel != orig
+}
+
+// drawTextSelection draws the selection of the text in l, whose upper-left corner is at origin.
+func (c *Context) drawTextSelection(l *textLayout, origin image.Point, sel textSelection) {
+	if sel.empty() {
+		return
+	}
+	start, end := sel.ordered()
+	for i, line := range l.lines {
+		s := max(start, line.start)
+		e := min(end, l.lineEnd(i))
+		if s > e || (s == e && end <= l.lineEnd(i)) {
+			continue
+		}
+		x0 := textWidth(l.text[line.start:s], l.face)
+		x1 := textWidth(l.text[line.start:e], l.face)
+		// Show the selected line break as a narrow area.
+		if end > l.lineEnd(i) && i < len(l.lines)-1 {
+			x1 += textWidth(" ", l.face)
+		}
+		y := i * l.lineHeight
+		c.drawRect(image.Rect(origin.X+x0, origin.Y+y, origin.X+x1, origin.Y+y+l.lineHeight), c.style().Colors[ColorSelection])
+	}
+}
+
+// drawCaret draws the caret at pos of the text in l, whose upper-left corner is at origin.
+func (c *Context) drawCaret(l *textLayout, origin image.Point, pos int) {
+	p := origin.Add(l.caretPosition(pos))
+	c.drawRect(image.Rect(p.X, p.Y, p.X+1, p.Y+l.lineHeight), c.style().Colors[ColorText])
+}
@@ -8,10 +8,9 @@ import (
 	"image"
 	"os"
 	"strconv"
-	"unicode/utf8"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
 )
 
 const (
@@ -21,6 +20,9 @@ const (
 
 // TextField creates a text field to modify the value of a string buf.
 //
+// The caret and the selection can be moved by the arrow keys, Home, End and the pointing device with Shift.
+// Ctrl+A, Ctrl+C, Ctrl+X and Ctrl+V select all, copy, cut and paste the text. See [DebugUI.SetClipboard].
+//
 // TextField returns an EventHandler to handle events when the value is confirmed, such as on blur or Enter key press.
 // A returned EventHandler is never nil.
 //
@@ -29,23 +31,29 @@ const (
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
 func (c *Context) TextField(buf *string) EventHandler {
 	pc := caller()
-	id := c.idStack.push(idPartFromCaller(pc))
+	id := c.idStack.push(c.nextIDPart(pc))
 	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
 		return c.textField(buf, id, 0)
 	})
 }
 
 func (c *Context) textFieldRaw(buf *string, id widgetID, opt option) (EventHandler, error) {
+	cnt := c.currentContainer()
 	return c.widget(id, opt|optionHoldFocus, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
 		var e EventHandler
 
-		f := c.currentContainer().textInputTextField(id, true)
+		f := cnt.textInputTextField(id, true)
 		if c.focus == id {
+			l := newTextLayout(*buf, 0, c.FontFace())
+			sel := cnt.textSelection(id, f)
+			sel, moved := c.handleTextEditPointing(l, c.textFieldOrigin(l, bounds, opt, sel.caret), sel)
+			if moved {
+				cnt.setTextAndSelection(id, f, *buf, sel)
+			}
+
 			// handle text input
-			f.Focus()
-			x := bounds.Min.X + c.style().padding + textWidth(*buf)
-			y := bounds.Min.Y + lineHeight()
-			handled, err := f.HandleInput(x, y)
+			pos := c.textFieldOrigin(l, bounds, opt, sel.caret).Add(l.caretPosition(sel.caret))
+			handled, err := c.handleTextInput(f, pos.X, pos.Y+l.lineHeight)
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
 				return nil
@@ -55,12 +63,12 @@ func (c *Context) textFieldRaw(buf *string, id widgetID, opt option) (EventHandl
 			}
 
 			if !handled {
-				if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(*buf) > 0 {
-					_, size := utf8.DecodeLastRuneInString(*buf)
-					*buf = (*buf)[:len(*buf)-size]
-					f.SetTextAndSelection(*buf, len(*buf), len(*buf))
+				if str, sel, changed := c.handleTextEditKeys(l, sel, false); changed {
+					cnt.setTextAndSelection(id, f, str, sel)
+					*buf = str
 				}
-				if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+				if c.keyboard.isKeyJustPressed(ebiten.KeyEnter) {
+					c.endEditGroup()
 					e = &eventHandler{}
 				}
 			}
@@ -71,35 +79,75 @@ func (c *Context) textFieldRaw(buf *string, id widgetID, opt option) (EventHandl
 			if wasFocused {
 				e = &eventHandler{}
 			}
+			// Enter or the gamepad's A button starts editing the text field with the navigation focus.
+			if c.navFocused(id) && c.navConfirmed() {
+				c.setFocus(id)
+			}
 		}
 		return e
 	}, func(bounds image.Rectangle) {
-		c.drawWidgetFrame(id, bounds, colorBase, opt)
+		c.drawWidgetFrame(id, bounds, ColorBase, opt)
 		if c.focus == id {
-			f := c.currentContainer().textInputTextField(id, true)
-
-			color := c.style().colors[colorText]
-			textw := textWidth(*buf)
-			texth := lineHeight()
-			ofx := bounds.Dx() - c.style().padding - textw - 1
-			textx := bounds.Min.X + min(ofx, c.style().padding)
-			switch {
-			case opt&optionAlignCenter != 0:
-				textx = bounds.Min.X + (bounds.Dx()-textw)/2
-			case opt&optionAlignRight != 0:
-				textx = bounds.Min.X + bounds.Dx() - textw - c.style().padding
-			}
-			texty := bounds.Min.Y + (bounds.Dy()-texth)/2
+			f := cnt.textInputTextField(id, true)
+			sel := cnt.textSelection(id, f)
+			// Show the text being composed with an IME.
+			l := newTextLayout(f.TextForRendering(), 0, c.FontFace())
+			origin := c.textFieldOrigin(l, bounds, opt, sel.caret)
 			c.pushClipRect(bounds)
-			c.drawText(f.TextForRendering(), image.Pt(textx, texty), color)
-			c.drawRect(image.Rect(textx+textw, texty, textx+textw+1, texty+texth), color)
+			c.drawTextSelection(l, origin, sel)
+			c.drawTextLayout(l, origin, ColorText)
+			c.drawCaret(l, origin, sel.caret)
 			c.popClipRect()
 		} else {
-			c.drawWidgetText(*buf, bounds, colorText, opt)
+			c.drawWidgetText(*buf, bounds, ColorText, opt)
 		}
 	})
 }
 
+// textFieldOrigin returns the upper-left corner of the text in the focused text field.
+//
+// If the text is wider than the text field, the text is scrolled so that its end is visible,
+// and then scrolled further so that the caret at caret is visible.
+func (c *Context) textFieldOrigin(l *textLayout, bounds image.Rectangle, opt option, caret int) image.Point {
+	padding := c.style().Padding
+	textw := textWidth(l.text, l.face)
+	ofx := bounds.Dx() - padding - textw - 1
+	x := bounds.Min.X + min(ofx, padding)
+	switch {
+	case opt&optionAlignCenter != 0:
+		x = bounds.Min.X + (bounds.Dx()-textw)/2
+	case opt&optionAlignRight != 0:
+		x = bounds.Min.X + bounds.Dx() - textw - padding
+	}
+	caretX := x + l.caretPosition(caret).X
+	if caretX < bounds.Min.X+padding {
+		x += bounds.Min.X + padding - caretX
+	} else if caretX > bounds.Max.X-padding-1 {
+		x -= caretX - (bounds.Max.X - padding - 1)
+	}
+	y := bounds.Min.Y + (bounds.Dy()-l.lineHeight)/2
+	return image.Pt(x, y)
+}
+
+// handleTextInput handles text input for the text field f.
+// x and y specify the position of the IME candidate window.
+func (c *Context) handleTextInput(f *textinput.Field, x, y int) (handled bool, err error) {
+	if c.usesEbitenInputSource() {
+		f.Focus()
+		return f.HandleInput(x, y)
+	}
+
+	// textinput.Field reads the platform's text input directly. Use the runes from the input source instead.
+	if len(c.keyboard.runes) == 0 {
+		return false, nil
+	}
+	text := f.Text()
+	start, end := f.Selection()
+	str := string(c.keyboard.runes)
+	f.SetTextAndSelection(text[:start]+str+text[end:], start+len(str), start+len(str))
+	return true, nil
+}
+
 // SetTextFieldValue sets the value of the current text field.
 //
 // If the last widget is not a text field, this function does nothing.
@@ -113,6 +161,7 @@ func (c *Context) SetTextFieldValue(value string) {
 }
 
 func (c *Context) textField(buf *string, id widgetID, opt option) (EventHandler, error) {
+	defer recordEdit(c, id, buf, *buf)
 	return c.textFieldRaw(buf, id, opt)
 }
 
@@ -128,7 +177,7 @@ func (c *Context) textField(buf *string, id widgetID, opt option) (EventHandler,
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
 func (c *Context) NumberField(value *int, step int) EventHandler {
 	pc := caller()
-	idPart := idPartFromCaller(pc)
+	idPart := c.nextIDPart(pc)
 	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
 		return c.numberField(value, step, idPart, optionAlignRight)
 	})
@@ -147,7 +196,7 @@ func (c *Context) NumberField(value *int, step int) EventHandler {
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
 func (c *Context) NumberFieldF(value *float64, step float64, digits int) EventHandler {
 	pc := caller()
-	idPart := idPartFromCaller(pc)
+	idPart := c.nextIDPart(pc)
 	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
 		return c.numberFieldF(value, step, digits, idPart, optionAlignRight)
 	})
@@ -159,8 +208,9 @@ func (c *Context) numberField(value *int, step int, idPart string, opt option) (
 	var e EventHandler
 	var err error
 	c.idScopeFromIDPart(idPart, func(id widgetID) {
+		defer recordEdit(c, id, value, last)
 		c.GridCell(func(bounds image.Rectangle) {
-			c.SetGridLayout([]int{-1, lineHeight()}, nil)
+			c.SetGridLayout([]int{-1, c.lineHeight()}, nil)
 
 			buf := fmt.Sprintf("%d", *value)
 			e1, err1 := c.textFieldRaw(&buf, id, opt)
@@ -181,19 +231,19 @@ func (c *Context) numberField(value *int, step int, idPart string, opt option) (
 					}
 				})
 			}
-			if c.focus == id {
+			if c.focus == id || c.navFocused(id) {
 				var updated bool
-				if keyRepeated(ebiten.KeyUp) || keyRepeated(ebiten.KeyDown) {
+				if c.keyboard.keyRepeated(ebiten.KeyUp) || c.keyboard.keyRepeated(ebiten.KeyDown) {
 					v, err := strconv.ParseInt(buf, 10, 64)
 					if err != nil {
 						v = 0
 					}
 					*value = int(v)
 					updated = true
-					if keyRepeated(ebiten.KeyUp) {
+					if c.keyboard.keyRepeated(ebiten.KeyUp) {
 						*value += step
 					}
-					if keyRepeated(ebiten.KeyDown) {
+					if c.keyboard.keyRepeated(ebiten.KeyDown) {
 						*value -= step
 						updated = true
 					}
@@ -235,8 +285,9 @@ func (c *Context) numberFieldF(value *float64, step float64, digits int, idPart
 	var e EventHandler
 	var err error
 	c.idScopeFromIDPart(idPart, func(id widgetID) {
+		defer recordEdit(c, id, value, last)
 		c.GridCell(func(bounds image.Rectangle) {
-			c.SetGridLayout([]int{-1, lineHeight()}, nil)
+			c.SetGridLayout([]int{-1, c.lineHeight()}, nil)
 
 			buf := formatNumber(*value, digits)
 			e1, err1 := c.textFieldRaw(&buf, id, opt)
@@ -257,19 +308,19 @@ func (c *Context) numberFieldF(value *float64, step float64, digits int, idPart
 					}
 				})
 			}
-			if c.focus == id {
+			if c.focus == id || c.navFocused(id) {
 				var updated bool
-				if keyRepeated(ebiten.KeyUp) || keyRepeated(ebiten.KeyDown) {
+				if c.keyboard.keyRepeated(ebiten.KeyUp) || c.keyboard.keyRepeated(ebiten.KeyDown) {
 					v, err := strconv.ParseFloat(buf, 64)
 					if err != nil {
 						v = 0
 					}
 					*value = float64(v)
 					updated = true
-					if keyRepeated(ebiten.KeyUp) {
+					if c.keyboard.keyRepeated(ebiten.KeyUp) {
 						*value += step
 					}
-					if keyRepeated(ebiten.KeyDown) {
+					if c.keyboard.keyRepeated(ebiten.KeyDown) {
 						*value -= step
 						updated = true
 					}
@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"slices"
+	"strings"
+)
+
+var colorSlotNames = [...]string{
+	ColorText:               "text",
+	ColorBorder:             "border",
+	ColorWindowBG:           "windowBG",
+	ColorTitleBG:            "titleBG",
+	ColorTitleBGTransparent: "titleBGTransparent",
+	ColorTitleText:          "titleText",
+	ColorPanelBG:            "panelBG",
+	ColorButton:             "button",
+	ColorButtonHover:        "buttonHover",
+	ColorButtonFocus:        "buttonFocus",
+	ColorBase:               "base",
+	ColorBaseHover:          "baseHover",
+	ColorBaseFocus:          "baseFocus",
+	ColorScrollBase:         "scrollBase",
+	ColorScrollThumb:        "scrollThumb",
+	ColorFocusRing:          "focusRing",
+	ColorSelection:          "selection",
+	ColorPlotGrid:           "plotGrid",
+	ColorModalDim:           "modalDim",
+}
+
+// String returns the name of the color slot used in JSON, like "text" or "windowBG".
+func (c ColorSlot) String() string {
+	if c < 0 || int(c) >= len(colorSlotNames) {
+		return fmt.Sprintf("ColorSlot(%d)", int(c))
+	}
+	return colorSlotNames[c]
+}
+
+// DarkTheme returns a dark theme. DarkTheme is the same as the default theme.
+func DarkTheme() *Theme {
+	return DefaultTheme()
+}
+
+// LightTheme returns a light theme.
+func LightTheme() *Theme {
+	t := DefaultTheme()
+	t.Colors = [...]color.RGBA{
+		ColorText:               {30, 30, 30, 255},
+		ColorBorder:             {170, 170, 170, 255},
+		ColorWindowBG:           {222, 222, 222, 235},
+		ColorTitleBG:            {210, 210, 210, 255},
+		ColorTitleBGTransparent: {176, 176, 176, 204},
+		ColorTitleText:          {20, 20, 20, 255},
+		ColorPanelBG:            {0, 0, 0, 0},
+		ColorButton:             {200, 200, 200, 255},
+		ColorButtonHover:        {185, 185, 185, 255},
+		ColorButtonFocus:        {170, 170, 170, 255},
+		ColorBase:               {255, 255, 255, 255},
+		ColorBaseHover:          {248, 248, 248, 255},
+		ColorBaseFocus:          {235, 235, 235, 255},
+		ColorScrollBase:         {225, 225, 225, 255},
+		ColorScrollThumb:        {180, 180, 180, 255},
+		ColorFocusRing:          {30, 110, 210, 255},
+		ColorSelection:          {170, 200, 240, 255},
+		ColorPlotGrid:           {220, 220, 220, 255},
+		ColorModalDim:           {0, 0, 0, 80},
+	}
+	return t
+}
+
+// HighContrastTheme returns a high-contrast theme, which is readable on any background.
+func HighContrastTheme() *Theme {
+	t := DefaultTheme()
+	t.Colors = [...]color.RGBA{
+		ColorText:               {255, 255, 255, 255},
+		ColorBorder:             {255, 255, 0, 255},
+		ColorWindowBG:           {0, 0, 0, 255},
+		ColorTitleBG:            {0, 0, 0, 255},
+		ColorTitleBGTransparent: {0, 0, 0, 230},
+		ColorTitleText:          {255, 255, 0, 255},
+		ColorPanelBG:            {0, 0, 0, 0},
+		ColorButton:             {0, 0, 0, 255},
+		ColorButtonHover:        {0, 70, 140, 255},
+		ColorButtonFocus:        {0, 110, 220, 255},
+		ColorBase:               {0, 0, 0, 255},
+		ColorBaseHover:          {30, 30, 30, 255},
+		ColorBaseFocus:          {0, 70, 140, 255},
+		ColorScrollBase:         {40, 40, 40, 255},
+		ColorScrollThumb:        {255, 255, 0, 255},
+		ColorFocusRing:          {0, 255, 255, 255},
+		ColorSelection:          {0, 90, 180, 255},
+		ColorPlotGrid:           {90, 90, 90, 255},
+		ColorModalDim:           {0, 0, 0, 200},
+	}
+	return t
+}
+
+type themeJSON struct {
+	DefaultWidth  *int                `json:"defaultWidth,omitempty"`
+	DefaultHeight *int                `json:"defaultHeight,omitempty"`
+	Padding       *int                `json:"padding,omitempty"`
+	Spacing       *int                `json:"spacing,omitempty"`
+	Indent        *int                `json:"indent,omitempty"`
+	TitleHeight   *int                `json:"titleHeight,omitempty"`
+	ScrollbarSize *int                `json:"scrollbarSize,omitempty"`
+	ThumbSize     *int                `json:"thumbSize,omitempty"`
+	Colors        map[string]hexColor `json:"colors,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// Colors are encoded as non-premultiplied hex strings like "#e6e6e6" or "#323232e6", keyed by the names of the color slots.
+func (t Theme) MarshalJSON() ([]byte, error) {
+	j := themeJSON{
+		DefaultWidth:  &t.DefaultWidth,
+		DefaultHeight: &t.DefaultHeight,
+		Padding:       &t.Padding,
+		Spacing:       &t.Spacing,
+		Indent:        &t.Indent,
+		TitleHeight:   &t.TitleHeight,
+		ScrollbarSize: &t.ScrollbarSize,
+		ThumbSize:     &t.ThumbSize,
+		Colors:        map[string]hexColor{},
+	}
+	for i, clr := range t.Colors {
+		j.Colors[ColorSlot(i).String()] = hexColor(clr)
+	}
+	return json.Marshal(&j)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Metrics and colors that are not in the JSON are kept as they are.
+// UnmarshalJSON returns an error for an unknown color slot, an invalid color, or a metric out of range,
+// e.g., a zero default height or a negative padding.
+// To override a part of a theme, unmarshal the JSON into a copy of the theme like DefaultTheme().
+func (t *Theme) UnmarshalJSON(data []byte) error {
+	var j themeJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	for name := range j.Colors {
+		if !slices.Contains(colorSlotNames[:], name) {
+			return fmt.Errorf("debugui: unknown color slot: %q", name)
+		}
+	}
+
+	// Sizes of widgets must be positive, while spaces between them can be zero.
+	metrics := []struct {
+		name string
+		src  *int
+		dst  *int
+		min  int
+	}{
+		{"defaultWidth", j.DefaultWidth, &t.DefaultWidth, 1},
+		{"defaultHeight", j.DefaultHeight, &t.DefaultHeight, 1},
+		{"padding", j.Padding, &t.Padding, 0},
+		{"spacing", j.Spacing, &t.Spacing, 0},
+		{"indent", j.Indent, &t.Indent, 0},
+		{"titleHeight", j.TitleHeight, &t.TitleHeight, 1},
+		{"scrollbarSize", j.ScrollbarSize, &t.ScrollbarSize, 1},
+		{"thumbSize", j.ThumbSize, &t.ThumbSize, 1},
+	}
+	for _, m := range metrics {
+		if m.src != nil && *m.src < m.min {
+			return fmt.Errorf("debugui: %s (%d) must be greater than or equal to %d", m.name, *m.src, m.min)
+		}
+	}
+
+	for _, m := range metrics {
+		if m.src != nil {
+			*m.dst = *m.src
+		}
+	}
+	for i := range t.Colors {
+		if clr, ok := j.Colors[ColorSlot(i).String()]; ok {
+			t.Colors[i] = color.RGBA(clr)
+		}
+	}
+	return nil
+}
+
+// hexColor is a color encoded as a hex string like "#rrggbb" or "#rrggbbaa".
+//
+// The hex string represents a non-premultiplied-alpha color as CSS does, while hexColor is premultiplied as color.RGBA is.
+type hexColor color.RGBA
+
+// MarshalText implements encoding.TextMarshaler.
+func (h hexColor) MarshalText() ([]byte, error) {
+	c := color.NRGBAModel.Convert(color.RGBA(h)).(color.NRGBA)
+	if c.A == 0xff {
+		return []byte(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)), nil
+	}
+	return []byte(fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (h *hexColor) UnmarshalText(text []byte) error {
+	str, ok := strings.CutPrefix(string(text), "#")
+	if !ok || (len(str) != 6 && len(str) != 8) {
+		return fmt.Errorf("debugui: invalid color: %q", string(text))
+	}
+	bs, err := hex.DecodeString(str)
+	if err != nil {
+		return fmt.Errorf("debugui: invalid color: %q: %w", string(text), err)
+	}
+	if len(bs) == 3 {
+		bs = append(bs, 0xff)
+	}
+	*h = hexColor(color.RGBAModel.Convert(color.NRGBA{R: bs[0], G: bs[1], B: bs[2], A: bs[3]}).(color.RGBA))
+	return nil
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// ThemeEditorWindow creates a window to edit the theme of the UI.
+//
+// The window lists all the metrics and the colors of the theme, and the changes are applied immediately.
+// The edited theme can be copied to the clipboard as JSON, which can be loaded by unmarshaling it into a Theme.
+// See also [DebugUI.SetClipboard].
+//
+// A ThemeEditorWindow is uniquely determined by its call location.
+func (c *Context) ThemeEditorWindow() {
+	pc := caller()
+	idPart := c.nextIDPart(pc)
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		if err := c.window("Theme Editor", image.Rect(40, 40, 340, 540), 0, idPart, func(layout ContainerLayout) {
+			c.themeEditor()
+		}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
+
+func (c *Context) themeEditor() {
+	if c.theme == nil {
+		c.theme = DefaultTheme()
+	}
+	theme := c.theme
+
+	c.Header("Presets", true, func() {
+		c.SetGridLayout([]int{-1, -1, -1}, nil)
+		c.Button("Dark").On(func() {
+			*theme = *DarkTheme()
+		})
+		c.Button("Light").On(func() {
+			*theme = *LightTheme()
+		})
+		c.Button("High Contrast").On(func() {
+			*theme = *HighContrastTheme()
+		})
+	})
+
+	c.Header("Metrics", true, func() {
+		c.SetGridLayout([]int{-2, -3}, nil)
+		for _, m := range []struct {
+			name  string
+			value *int
+			low   int
+			high  int
+		}{
+			{"Default Width", &theme.DefaultWidth, 10, 200},
+			{"Default Height", &theme.DefaultHeight, 10, 40},
+			{"Padding", &theme.Padding, 0, 20},
+			{"Spacing", &theme.Spacing, 0, 20},
+			{"Indent", &theme.Indent, 0, 40},
+			{"Title Height", &theme.TitleHeight, 12, 48},
+			{"Scrollbar Size", &theme.ScrollbarSize, 4, 32},
+			{"Thumb Size", &theme.ThumbSize, 4, 32},
+		} {
+			c.IDScope(m.name, func() {
+				c.Text(m.name + ":")
+				c.Slider(m.value, m.low, m.high, 1)
+			})
+		}
+	})
+
+	c.Header("Colors", true, func() {
+		for i := range theme.Colors {
+			slot := ColorSlot(i)
+			c.SetNextKey(slot.String())
+			c.TreeNode(slot.String(), func() {
+				c.colorEditor(&theme.Colors[slot])
+			})
+		}
+	})
+
+	c.Header("Export", false, func() {
+		c.SetGridLayout([]int{-1}, nil)
+		c.Button("Copy JSON to Clipboard").On(func() {
+			_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+				data, err := json.MarshalIndent(theme, "", "  ")
+				if err != nil {
+					return nil, fmt.Errorf("debugui: encoding theme failed: %w", err)
+				}
+				if err := c.currentClipboard().WriteText(string(data)); err != nil {
+					return nil, fmt.Errorf("debugui: writing theme to clipboard failed: %w", err)
+				}
+				return nil, nil
+			})
+		})
+	})
+}
+
+// colorEditor shows sliders to edit each channel of the color, and a preview of the color.
+//
+// The channels are edited in the non-premultiplied alpha form.
+func (c *Context) colorEditor(clr *color.RGBA) {
+	nclr := color.NRGBAModel.Convert(*clr).(color.NRGBA)
+	channels := [...]int{int(nclr.R), int(nclr.G), int(nclr.B), int(nclr.A)}
+
+	c.SetGridLayout([]int{-1, -3}, nil)
+	for i, name := range [...]string{"R", "G", "B", "A"} {
+		c.Text(name + ":")
+		c.SetNextKey(name)
+		id := c.idStack.push(c.nextIDPart(caller()))
+		before := *clr
+		// The channels are temporary values, so record the edit of clr instead of the channel.
+		c.withoutEditHistory(func() {
+			c.wrapEventHandlerAndError(func() (EventHandler, error) {
+				return c.slider(&channels[i], 0, 255, 1, id, optionAlignCenter)
+			}).On(func() {
+				// Update the color only when the value is changed, as the conversion between premultiplied and non-premultiplied colors is lossy.
+				*clr = color.RGBAModel.Convert(color.NRGBA{
+					R: uint8(channels[0]),
+					G: uint8(channels[1]),
+					B: uint8(channels[2]),
+					A: uint8(channels[3]),
+				}).(color.RGBA)
+			})
+		})
+		recordEdit(c, id, clr, before)
+	}
+
+	c.SetGridLayout([]int{-1}, nil)
+	c.GridCell(func(bounds image.Rectangle) {
+		c.drawRect(bounds, *clr)
+		c.drawBox(bounds, c.style().Colors[ColorBorder])
+	})
+}
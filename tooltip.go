@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"image"
+	"strings"
+)
+
+const (
+	// tooltipMaxWidth is the maximum width of a text tooltip. A longer text is wrapped.
+	tooltipMaxWidth = 240
+
+	// tooltipOffset is the offset of a tooltip from the pointing position.
+	tooltipOffset = 16
+)
+
+var tooltipIDPart = idPartFromString("debugui-tooltip")
+
+// Tooltip shows a tooltip with the text when the pointing device hovers over the last widget for a while.
+// The tooltip is shown near the pointing position, inside the screen and above all the windows.
+//
+// Tooltip must be called right after the widget is created, e.g.,
+//
+//	ctx.Slider(&gravity, 0, 100, 1)
+//	ctx.Tooltip("The gravity applied to the player")
+//
+// Tooltip does nothing for a widget without user interaction like Text.
+func (c *Context) Tooltip(text string) {
+	c.TooltipFunc(c.textTooltipContent(text))
+}
+
+// textTooltipContent returns a function to create the content of a tooltip with the text.
+func (c *Context) textTooltipContent(text string) func(layout ContainerLayout) {
+	return func(layout ContainerLayout) {
+		var w int
+		for _, line := range strings.Split(text, "\n") {
+			w = max(w, c.textWidth(line))
+		}
+		c.SetGridLayout([]int{min(w+c.style().Padding, tooltipMaxWidth)}, nil)
+		c.Text(text)
+	}
+}
+
+// TooltipFunc is like Tooltip, but the content of the tooltip is created by f.
+//
+// The tooltip is resized to fit its content.
+// The content of a tooltip doesn't interact with the user.
+func (c *Context) TooltipFunc(f func(layout ContainerLayout)) {
+	id := c.currentID
+	if id == (widgetID{}) || c.hover != id {
+		return
+	}
+	if c.tooltipID != id {
+		c.tooltipID = id
+		c.tooltipTicks = 0
+	}
+	// Pressing the widget hides the tooltip until the delay passes again.
+	if c.pointing.pressed() {
+		c.tooltipTicks = 0
+		return
+	}
+	c.tooltipTicks++
+	if c.tooltipTicks < tooltipDelay() {
+		return
+	}
+	c.tooltipContent = f
+}
+
+// tooltipDelay returns the number of ticks to hover over a widget before its tooltip is shown.
+func tooltipDelay() int {
+	return tps() / 2
+}
+
+// drawTooltip draws the tooltip requested in the current frame, above all the other root containers.
+func (c *Context) drawTooltip() {
+	if c.hover != c.tooltipID {
+		c.tooltipID = widgetID{}
+	}
+	f := c.tooltipContent
+	c.tooltipContent = nil
+	if f == nil {
+		return
+	}
+
+	_ = c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		opt := optionNoInteract | optionNoResize | optionNoScroll | optionNoTitle
+		cnt := c.container(c.idStack.push(tooltipIDPart), opt)
+		cnt.open = true
+		c.bringToFront(cnt)
+
+		// Place the tooltip at the lower right of the pointing position, and move it to keep it inside the screen.
+		if err := c.fittingWindow(cnt, "", opt, tooltipIDPart, func(size image.Point) image.Point {
+			pt := c.pointingPosition()
+			screen := c.screenSizeInUI()
+			return popupPosition(pt.Add(image.Pt(tooltipOffset, tooltipOffset)), image.Pt(screen.X, pt.Y-tooltipOffset/2), size, screen)
+		}, f); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
@@ -5,6 +5,9 @@ package debugui
 
 import (
 	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
 )
 
 // widgetID is a unique identifier for a widget.
@@ -49,6 +52,9 @@ const (
 	optionPopup
 	optionClosed
 	optionExpanded
+	optionNoNavigation
+	optionModal
+	optionNoKeyPath
 )
 
 func (c *Context) pointingOver(bounds image.Rectangle) bool {
@@ -66,10 +72,15 @@ func (c *Context) pointingDelta() image.Point {
 	return c.pointingPosition().Sub(c.lastPointingPos)
 }
 
+// screenSizeInUI returns the screen size in the UI coordinate.
+func (c *Context) screenSizeInUI() image.Point {
+	return image.Pt(int(float64(c.screenWidth)/c.Scale()), int(float64(c.screenHeight)/c.Scale()))
+}
+
 func (c *Context) pointingPosition() image.Point {
 	p := c.pointing.position()
-	p.X /= c.Scale()
-	p.Y /= c.Scale()
+	p.X = int(math.Floor(float64(p.X) / c.Scale()))
+	p.Y = int(math.Floor(float64(p.Y) / c.Scale()))
 	return p
 }
 
@@ -99,6 +110,11 @@ func (c *Context) handleInputForWidget(id widgetID, bounds image.Rectangle, opt
 			c.setFocus(widgetID{})
 			wasFocused = true
 		}
+		// Tab moves the navigation focus to the next widget, so blur the widget.
+		if c.keyboard.keyRepeated(ebiten.KeyTab) {
+			c.setFocus(widgetID{})
+			wasFocused = true
+		}
 	}
 
 	if c.hover == id {
@@ -148,9 +164,11 @@ func (c *Context) widget(id widgetID, opt option, layout func(bounds image.Recta
 		return e, nil
 	}
 
+	c.registerFocusable(id, bounds, opt)
 	if draw != nil {
-		draw(bounds)
+		c.drawWidget(id, bounds, draw)
 	}
+	c.drawFocusRing(id, bounds)
 	return e, nil
 }
 
@@ -163,7 +181,7 @@ func (c *Context) widgetWithBounds(id widgetID, opt option, bounds image.Rectang
 		e = handleInput(bounds, wasFocused)
 	}
 	if draw != nil {
-		draw(bounds)
+		c.drawWidget(id, bounds, draw)
 	}
 	return e
 }
@@ -175,30 +193,37 @@ func (c *Context) widgetWithBounds(id widgetID, opt option, bounds image.Rectang
 // If you want to generate different widgets with the same function call in a loop (such as a for loop), use [IDScope].
 func (c *Context) Checkbox(state *bool, label string) EventHandler {
 	pc := caller()
-	id := c.idStack.push(idPartFromCaller(pc))
+	id := c.idStack.push(c.nextIDPart(pc))
 	return c.wrapEventHandlerAndError(func() (EventHandler, error) {
+		defer recordEdit(c, id, state, *state)
 		return c.widget(id, 0, nil, func(bounds image.Rectangle, wasFocused bool) EventHandler {
 			var e EventHandler
 			c.handleInputForWidget(id, bounds, 0)
-			if c.pointing.justPressed() && c.focus == id {
+			if (c.pointing.justPressed() && c.focus == id) || c.navActivated(id) {
 				e = &eventHandler{}
 				*state = !*state
 			}
 			return e
 		}, func(bounds image.Rectangle) {
-			box := image.Rect(bounds.Min.X, bounds.Min.Y+(bounds.Dy()-lineHeight())/2, bounds.Min.X+lineHeight(), bounds.Max.Y-(bounds.Dy()-lineHeight())/2)
-			c.drawWidgetFrame(id, box, colorBase, 0)
-			if *state {
-				c.drawIcon(iconCheck, box, c.style().colors[colorText])
-			}
-			if label != "" {
-				bounds = image.Rect(bounds.Min.X+lineHeight(), bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
-				c.drawWidgetText(label, bounds, colorText, 0)
-			}
+			c.drawCheckWidget(id, bounds, label, IconCheck, *state)
 		})
 	})
 }
 
+// drawCheckWidget draws a widget with a box at the left of the label, like a checkbox or a radio button.
+// The icon is drawn in the box if checked is true.
+func (c *Context) drawCheckWidget(id widgetID, bounds image.Rectangle, label string, icon Icon, checked bool) {
+	box := image.Rect(bounds.Min.X, bounds.Min.Y+(bounds.Dy()-c.lineHeight())/2, bounds.Min.X+c.lineHeight(), bounds.Max.Y-(bounds.Dy()-c.lineHeight())/2)
+	c.drawWidgetFrame(id, box, ColorBase, 0)
+	if checked {
+		c.drawIcon(icon, box, c.style().Colors[ColorText])
+	}
+	if label != "" {
+		bounds = image.Rect(bounds.Min.X+c.lineHeight(), bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
+		c.drawWidgetText(label, bounds, ColorText, 0)
+	}
+}
+
 func (c *Context) setFocus(id widgetID) {
 	c.focus = id
 	c.keepFocus = true
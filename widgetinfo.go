@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The Ebitengine Authors
+
+package debugui
+
+import (
+	"cmp"
+	"image"
+	"iter"
+	"strings"
+)
+
+// WidgetInfo represents a widget that was laid out in the last Update.
+//
+// WidgetInfo is intended for tools like tests. See also the debuguitest package.
+type WidgetInfo struct {
+	// Label is the text drawn for the widget, e.g., the text of a button or the title of a window.
+	// If the widget draws multiple texts, Label is the texts joined with a space.
+	Label string
+
+	// Path is the ID path of the widget like "Demo Window/Game Config/Hi-Res".
+	//
+	// Path consists of the keys of the widget's window, panels, headers and the widget itself, joined with '/'.
+	// If a window, a header or the widget doesn't have a key set by [Context.SetNextKey], its title or label is used instead.
+	// Path is empty if the widget has neither a key nor a label.
+	Path string
+
+	// Bounds is the visible bounds of the widget in the screen coordinate.
+	Bounds image.Rectangle
+}
+
+type widgetInfo struct {
+	id     widgetID
+	bounds image.Rectangle
+	labels []string
+
+	// parentPath is the ID path of the widget's parent.
+	parentPath string
+
+	// key is the key of the widget, or an empty string if the widget doesn't have a key.
+	key string
+}
+
+// Widgets returns a sequence of the widgets that were laid out in the last Update.
+//
+// The widgets are ordered by the z-order of their windows, from back to front.
+// Widgets that are not visible, e.g., widgets in a collapsed header, are not included.
+func (d *DebugUI) Widgets() iter.Seq[WidgetInfo] {
+	return d.ctx.widgetInfos()
+}
+
+func (c *Context) widgetInfos() iter.Seq[WidgetInfo] {
+	return func(yield func(WidgetInfo) bool) {
+		scale := c.Scale()
+		for _, cnt := range c.rootContainers {
+			for _, info := range cnt.widgetInfos {
+				if info.id == (widgetID{}) && len(info.labels) == 0 {
+					continue
+				}
+				if !yield(WidgetInfo{
+					Label:  info.label(),
+					Path:   info.path(),
+					Bounds: scaleRect(info.bounds, scale),
+				}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (w *widgetInfo) label() string {
+	return strings.Join(w.labels, " ")
+}
+
+// path returns the ID path of the widget. See WidgetInfo.Path.
+func (w *widgetInfo) path() string {
+	key := cmp.Or(w.key, w.label())
+	if key == "" {
+		return ""
+	}
+	if w.parentPath == "" {
+		return key
+	}
+	return w.parentPath + "/" + key
+}
+
+// drawWidget calls draw for the widget, and records the widget's information.
+func (c *Context) drawWidget(id widgetID, bounds image.Rectangle, draw func(bounds image.Rectangle)) {
+	cnt := c.currentRootContainer()
+	key, _ := keyFromID(id)
+	cnt.widgetInfos = append(cnt.widgetInfos, widgetInfo{
+		id:         id,
+		bounds:     bounds.Intersect(c.clipRect()),
+		parentPath: c.currentKeyPath(),
+		key:        key,
+	})
+	c.drawingWidgetInfo = &cnt.widgetInfos[len(cnt.widgetInfos)-1]
+	defer func() {
+		c.drawingWidgetInfo = nil
+	}()
+	draw(bounds)
+}
+
+// setDrawingWidgetKey sets the key of the widget being drawn.
+func (c *Context) setDrawingWidgetKey(key string) {
+	if c.drawingWidgetInfo == nil {
+		return
+	}
+	c.drawingWidgetInfo.key = key
+}